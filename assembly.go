@@ -0,0 +1,258 @@
+package golis
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Assembler is buffer of matrix elements for concurrent assembly
+// of sparse matrix. Each goroutine must use own Assembler, so method
+// Add is not locked. Buffered elements are added to matrix by method
+// Merge of matrix.
+//
+// Example:
+//
+//	var wg sync.WaitGroup
+//	for w := 0; w < workers; w++ {
+//		wg.Add(1)
+//		go func(w int) {
+//			defer wg.Done()
+//			a := m.Assembler()
+//			for _, e := range elements[w] {
+//				a.Add(e.r, e.c, e.v)
+//			}
+//			m.Merge(a)
+//		}(w)
+//	}
+//	wg.Wait()
+type Assembler struct {
+	r, c      int  // sizes of matrix
+	symmetric bool // only upper triangle elements
+	ts        []triple
+}
+
+// Assembler returns new empty buffer of elements for matrix
+func (m *SparseMatrix) Assembler() *Assembler {
+	return &Assembler{r: m.r, c: m.c}
+}
+
+// Assembler returns new empty buffer of elements for matrix.
+// Only upper triangle elements can be added.
+func (m *SparseMatrixSymmetric) Assembler() *Assembler {
+	return &Assembler{r: m.s.r, c: m.s.c, symmetric: true}
+}
+
+// Add is addition value to buffer of matrix element.
+// If r,c outside of matrix, then create a panic.
+// If value is not valid, then create panic.
+func (a *Assembler) Add(r, c int, value float64) {
+	checkIndexes(r, c, a.r, a.c)
+	if a.symmetric && r > c {
+		panic(fmt.Errorf("SparseMatrixSymmetric have only upper value: %d <= %d", r, c))
+	}
+	checkValue(value)
+	if math.Abs(value) == 0.0 { // no need addition zero value
+		return
+	}
+	a.ts = append(a.ts, triple{
+		position: int64(r) + int64(c)*int64(a.r),
+		d:        value,
+	})
+}
+
+// Merge adds all buffered elements of assemblers to matrix and empties
+// the assemblers. Merges are serialized by lock of matrix, so Merge is
+// safe for concurrent use with other merges, but not with other methods
+// of matrix. Assemblers must not be used by other goroutines during
+// merging.
+// Elements are added in order of assemblers and values with same
+// indexes are summarized in order of addition.
+func (m *SparseMatrix) Merge(as ...*Assembler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, a := range as {
+		if a.r != m.r || a.c != m.c {
+			panic(fmt.Errorf("Sizes of assembler is not same: [%d,%d] != [%d,%d]",
+				a.r, a.c, m.r, m.c))
+		}
+		if len(a.ts) == 0 {
+			continue
+		}
+		m.data.ts = append(m.data.ts, a.ts...)
+		m.data.amountAdded += len(a.ts)
+		a.ts = a.ts[:0]
+	}
+	m.compressLocked()
+}
+
+// Merge adds all buffered elements of assemblers to matrix and empties
+// the assemblers. See SparseMatrix.Merge.
+func (m *SparseMatrixSymmetric) Merge(as ...*Assembler) {
+	for _, a := range as {
+		if !a.symmetric {
+			panic(fmt.Errorf("Assembler is not for symmetric matrix"))
+		}
+	}
+	m.s.Merge(as...)
+}
+
+// Assemble calls function fn for each element index from 0 to amount-1
+// concurrently by workers goroutines. Each goroutine have own assembler
+// for range of elements and after all assemblers are merged in order
+// of elements. Values with same indexes are summarized in order of
+// addition, so result is same as in sequential assembly by method Add
+// for any amount of workers, including rounding of values.
+// Panic in function fn is repeated in caller and matrix is not changed.
+// If amount is zero or less, then matrix is not changed.
+func (m *SparseMatrix) Assemble(amount, workers int, fn func(a *Assembler, element int)) {
+	m.Merge(assemble(m.Assembler, amount, workers, fn)...)
+}
+
+// Assemble calls function fn for each element index from 0 to amount-1
+// concurrently by workers goroutines. See SparseMatrix.Assemble.
+func (m *SparseMatrixSymmetric) Assemble(amount, workers int, fn func(a *Assembler, element int)) {
+	m.Merge(assemble(m.Assembler, amount, workers, fn)...)
+}
+
+// assemble returns assemblers filled concurrently for ranges of elements
+func assemble(create func() *Assembler, amount, workers int,
+	fn func(a *Assembler, element int)) []*Assembler {
+	if amount <= 0 {
+		return nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > amount {
+		workers = amount
+	}
+	as := make([]*Assembler, workers)
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		p    interface{} // first panic in goroutines
+	)
+	for w := range as {
+		as[w] = create()
+		wg.Add(1)
+		go func(a *Assembler, from, to int) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { p = r })
+				}
+			}()
+			for e := from; e < to; e++ {
+				fn(a, e)
+			}
+		}(as[w], w*amount/workers, (w+1)*amount/workers)
+	}
+	wg.Wait()
+	if p != nil {
+		// panic in goroutine is repeated in caller
+		panic(p)
+	}
+	return as
+}
+
+// AddBlock is addition of dense element matrix ke to buffer of matrix
+// elements. See SparseMatrix.AddBlock.
+func (a *Assembler) AddBlock(indices []int, ke mat.Matrix) {
+	a.ts = blockTriples(a.ts, a.r, a.c, a.symmetric, indices, ke)
+}
+
+// AddBlock is addition of dense element matrix ke to matrix by
+// global indexes of element rows and columns:
+//
+//	m[indices[i], indices[j]] += ke[i,j]
+//
+// Element rows and columns with negative indexes are ignored, for
+// example fixed degree of freedom. If indexes outside of matrix or
+// values of ke is not valid, then create panic and matrix is not changed.
+func (m *SparseMatrix) AddBlock(indices []int, ke mat.Matrix) {
+	size := len(m.data.ts)
+	m.data.ts = blockTriples(m.data.ts, m.r, m.c, false, indices, ke)
+	m.addedBlock(len(m.data.ts) - size)
+}
+
+// AddBlock is addition of dense element matrix ke to matrix by
+// global indexes of element rows and columns. Only elements of ke with
+// global indexes in upper triangle (row <= column) are added, so ke
+// must be symmetric. See SparseMatrix.AddBlock.
+func (m *SparseMatrixSymmetric) AddBlock(indices []int, ke mat.Matrix) {
+	size := len(m.s.data.ts)
+	m.s.data.ts = blockTriples(m.s.data.ts, m.s.r, m.s.c, true, indices, ke)
+	m.s.addedBlock(len(m.s.data.ts) - size)
+}
+
+// addedBlock updates amount of added triples and compress matrix
+// if amount of added triples is too big. Same as in method Add.
+func (m *SparseMatrix) addedBlock(amount int) {
+	m.data.amountAdded += amount
+	max := m.c
+	if m.r > m.c {
+		max = m.r
+	}
+	if m.data.amountAdded > max {
+		m.compress()
+	}
+}
+
+// blockTriples returns triples ts with appended non-zero elements
+// of element matrix ke. All indexes and values are checked before
+// appending.
+func blockTriples(ts []triple, rows, cols int, symmetric bool,
+	indices []int, ke mat.Matrix) []triple {
+	if r, c := ke.Dims(); r != len(indices) || c != len(indices) {
+		panic(fmt.Errorf("Sizes of element matrix is not same with indexes: [%d,%d] != %d",
+			r, c, len(indices)))
+	}
+	for _, index := range indices {
+		if index < 0 {
+			continue
+		}
+		checkIndexes(index, index, rows, cols)
+	}
+	amount := 0
+	for i, r := range indices {
+		if r < 0 {
+			continue
+		}
+		for j, c := range indices {
+			if c < 0 || (symmetric && r > c) {
+				continue
+			}
+			v := ke.At(i, j)
+			checkValue(v)
+			if math.Abs(v) != 0.0 {
+				amount++
+			}
+		}
+	}
+	if cap(ts)-len(ts) < amount {
+		// amortized growth of slice
+		ts = append(ts, make([]triple, amount)...)[:len(ts)]
+	}
+	for i, r := range indices {
+		if r < 0 {
+			continue
+		}
+		for j, c := range indices {
+			if c < 0 || (symmetric && r > c) {
+				continue
+			}
+			v := ke.At(i, j)
+			if math.Abs(v) == 0.0 { // no need addition zero value
+				continue
+			}
+			ts = append(ts, triple{
+				position: int64(r) + int64(c)*int64(rows),
+				d:        v,
+			})
+		}
+	}
+	return ts
+}
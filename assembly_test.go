@@ -0,0 +1,278 @@
+package golis_test
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+// element returns indexes and values of beam element with 2 nodes
+// and 2 degree of freedom in each node
+func element(e int) (dofs []int, ke [4][4]float64) {
+	dofs = []int{2 * e, 2*e + 1, 2*e + 2, 2*e + 3}
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			// values are not exact in binary format, so result
+			// depends on order of summation
+			ke[i][j] = float64((i+1)*(j+1))*0.1 + float64(e%7)/3.0
+		}
+	}
+	return
+}
+
+// nonZeroDoer is matrix with iteration of non-zero elements
+type nonZeroDoer interface {
+	DoNonZero(fn func(i, j int, v float64))
+}
+
+// sameNonZeros returns true, if non-zero elements of matrixes have
+// same indexes and values with tolerance tol. If tol is zero, then
+// values must be exactly same.
+func sameNonZeros(a, b nonZeroDoer, tol float64) bool {
+	type element struct {
+		i, j int
+		v    float64
+	}
+	values := func(m nonZeroDoer) (es []element) {
+		m.DoNonZero(func(i, j int, v float64) {
+			es = append(es, element{i: i, j: j, v: v})
+		})
+		return
+	}
+	ea, eb := values(a), values(b)
+	if len(ea) != len(eb) {
+		return false
+	}
+	for k := range ea {
+		if ea[k].i != eb[k].i || ea[k].j != eb[k].j ||
+			math.Abs(ea[k].v-eb[k].v) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAssemble(t *testing.T) {
+	amount := 1000
+	size := 2*amount + 2
+
+	// sequential assembly
+	seq := golis.NewSparseMatrix(size, size)
+	seqSym := golis.NewSparseMatrixSymmetric(size)
+	for e := 0; e < amount; e++ {
+		dofs, ke := element(e)
+		for i := range dofs {
+			for j := range dofs {
+				seq.Add(dofs[i], dofs[j], ke[i][j])
+				if dofs[i] <= dofs[j] {
+					seqSym.Add(dofs[i], dofs[j], ke[i][j])
+				}
+			}
+		}
+	}
+
+	for _, workers := range []int{0, 1, 3, 8, 2000} {
+		t.Run(fmt.Sprintf("Assemble%d", workers), func(t *testing.T) {
+			m := golis.NewSparseMatrix(size, size)
+			m.Assemble(amount, workers, func(a *golis.Assembler, e int) {
+				dofs, ke := element(e)
+				for i := range dofs {
+					for j := range dofs {
+						a.Add(dofs[i], dofs[j], ke[i][j])
+					}
+				}
+			})
+			if !sameNonZeros(m, seq, 0) {
+				t.Fatalf("Not same with sequential assembly")
+			}
+
+			s := golis.NewSparseMatrixSymmetric(size)
+			s.Assemble(amount, workers, func(a *golis.Assembler, e int) {
+				dofs, ke := element(e)
+				for i := range dofs {
+					for j := range dofs {
+						if dofs[i] <= dofs[j] {
+							a.Add(dofs[i], dofs[j], ke[i][j])
+						}
+					}
+				}
+			})
+			if !sameNonZeros(s, seqSym, 0) {
+				t.Fatalf("Not same with sequential assembly")
+			}
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		m := golis.NewSparseMatrix(size, size)
+		for _, amount := range []int{0, -1} {
+			m.Assemble(amount, 4, func(a *golis.Assembler, e int) {
+				t.Fatalf("Function is called for element %d", e)
+			})
+		}
+		if nonZeros(m) != "" {
+			t.Fatalf("Matrix is changed")
+		}
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		m := golis.NewSparseMatrix(size, size)
+		var wg sync.WaitGroup
+		workers := 4
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				a := m.Assembler()
+				for e := w; e < amount; e += workers {
+					dofs, ke := element(e)
+					for i := range dofs {
+						for j := range dofs {
+							a.Add(dofs[i], dofs[j], ke[i][j])
+						}
+					}
+					if e%50 == 0 {
+						m.Merge(a)
+					}
+				}
+				m.Merge(a)
+			}(w)
+		}
+		wg.Wait()
+		// order of concurrent merges is not defined,
+		// so values can be different in last bits
+		if !sameNonZeros(m, seq, 1e-12) {
+			t.Fatalf("Not same with sequential assembly")
+		}
+	})
+}
+
+func TestAssemblePanics(t *testing.T) {
+	m := golis.NewSparseMatrix(3, 3)
+	s := golis.NewSparseMatrixSymmetric(3)
+	for i, f := range []func(){
+		func() { m.Assembler().Add(3, 0, 1.0) },
+		func() { s.Assembler().Add(1, 0, 1.0) },
+		func() { m.Merge(golis.NewSparseMatrix(2, 2).Assembler()) },
+		func() { s.Merge(m.Assembler()) },
+		func() {
+			m.Assemble(10, 4, func(a *golis.Assembler, e int) {
+				a.Add(e, e, 1.0)
+			})
+		},
+	} {
+		t.Run(fmt.Sprintf("Panic%d", i), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				t.Logf("\n%v", r)
+				if r == nil {
+					t.Fatal("Haven`t panic for not valid data")
+				}
+			}()
+			f()
+		})
+	}
+}
+
+func TestAddBlock(t *testing.T) {
+	amount := 50
+	size := 2*amount + 2
+	ignored := 0 // ignored degree of freedom
+
+	// element matrix with ignored degree of freedom
+	block := func(e int) (indices []int, ke *mat.Dense) {
+		dofs, k := element(e)
+		ke = mat.NewDense(4, 4, nil)
+		for i := range dofs {
+			for j := range dofs {
+				ke.Set(i, j, k[i][j])
+			}
+		}
+		for i := range dofs {
+			if dofs[i] == ignored {
+				dofs[i] = -1
+			}
+		}
+		return dofs, ke
+	}
+
+	seq := golis.NewSparseMatrix(size, size)
+	seqSym := golis.NewSparseMatrixSymmetric(size)
+	for e := 0; e < amount; e++ {
+		dofs, ke := element(e)
+		for i := range dofs {
+			for j := range dofs {
+				if dofs[i] == ignored || dofs[j] == ignored {
+					continue
+				}
+				seq.Add(dofs[i], dofs[j], ke[i][j])
+				if dofs[i] <= dofs[j] {
+					seqSym.Add(dofs[i], dofs[j], ke[i][j])
+				}
+			}
+		}
+	}
+
+	m := golis.NewSparseMatrix(size, size)
+	s := golis.NewSparseMatrixSymmetric(size)
+	for e := 0; e < amount; e++ {
+		indices, ke := block(e)
+		m.AddBlock(indices, ke)
+		s.AddBlock(indices, ke)
+	}
+	if !sameNonZeros(m, seq, 0) {
+		t.Fatalf("Not same with Add")
+	}
+	if !sameNonZeros(s, seqSym, 0) {
+		t.Fatalf("Not same with Add")
+	}
+
+	am := golis.NewSparseMatrix(size, size)
+	am.Assemble(amount, 4, func(a *golis.Assembler, e int) {
+		a.AddBlock(block(e))
+	})
+	if !sameNonZeros(am, seq, 0) {
+		t.Fatalf("Not same with Add")
+	}
+}
+
+func TestAddBlockPanics(t *testing.T) {
+	ke := mat.NewDense(2, 2, []float64{1, 2, 2, math.NaN()})
+	for i, f := range []func(){
+		func() { golis.NewSparseMatrix(3, 3).AddBlock([]int{0}, ke) },
+		func() { golis.NewSparseMatrix(3, 3).AddBlock([]int{0, 3}, ke) },
+		func() { golis.NewSparseMatrix(3, 3).AddBlock([]int{0, 1}, ke) },
+		func() { golis.NewSparseMatrixSymmetric(3).AddBlock([]int{1, 2}, ke) },
+	} {
+		t.Run(fmt.Sprintf("Panic%d", i), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				t.Logf("\n%v", r)
+				if r == nil {
+					t.Fatal("Haven`t panic for not valid data")
+				}
+			}()
+			f()
+		})
+	}
+
+	// matrix is not changed after panic
+	m := golis.NewSparseMatrix(3, 3)
+	func() {
+		defer func() { _ = recover() }()
+		m.AddBlock([]int{0, 1}, ke)
+	}()
+	if nnz := len(nonZeros(m)); nnz != 0 {
+		t.Fatalf("Matrix is changed after panic")
+	}
+
+	// values of ignored indexes are not checked
+	m.AddBlock([]int{2, -1}, ke)
+	if v := m.At(2, 2); v != 1.0 {
+		t.Fatalf("Not valid value: %v", v)
+	}
+}
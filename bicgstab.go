@@ -0,0 +1,126 @@
+package golis
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// BiCGSTAB returns solution matrix of BiCGSTAB iterative solve for
+// linear system without external `lis` software.
+//
+//	A * x = b
+//
+// Where: A is matrix, b is right-hand vector.
+//
+// Iterations are finished, if relative residual norm ||b-A*x||/||b||
+// is less or equal tol. Solution and residual history have same
+// format as result of function Lsolve.
+// If solution is not found after maxiter iterations, then error Maxiter
+// is returned. Error Breakdown is returned in case of method breakdown.
+func BiCGSTAB(A *SparseMatrix, b mat.Matrix, tol float64, maxiter int) (
+	solution mat.Matrix,
+	rhistory []float64,
+	err error) {
+
+	if err = checkSystem(A, b); err != nil {
+		return
+	}
+	if err = checkIterative(tol, maxiter); err != nil {
+		return
+	}
+
+	x, rhistory, err := bicgstab(A, vectorFromMatrix(b), nil, nil, tol, maxiter)
+	if err != nil {
+		return
+	}
+	n, _ := b.Dims()
+	solution = mat.NewDense(n, 1, x)
+	return
+}
+
+// bicgstab is right preconditioned BiConjugate Gradient Stabilized method.
+// If x0 is nil, then initial guess is zero.
+// If M is nil, then method is without preconditioner.
+func bicgstab(A matVec, b, x0 []float64, M Preconditioner, tol float64, maxiter int) (
+	x []float64,
+	rhistory []float64,
+	err error) {
+
+	n := len(b)
+	var (
+		r    = make([]float64, n)
+		rhat = make([]float64, n)
+		p    = make([]float64, n)
+		ph   = make([]float64, n) // preconditioned p
+		v    = make([]float64, n)
+		s    = make([]float64, n)
+		sh   = make([]float64, n) // preconditioned s
+		t    = make([]float64, n)
+	)
+	bnorm := floats.Norm(b, 2)
+	if bnorm == 0.0 {
+		// trivial solution
+		return make([]float64, n), []float64{0.0}, nil
+	}
+	x = initial(A, b, x0, r)
+	resid := floats.Norm(r, 2) / bnorm
+	rhistory = append(rhistory, resid)
+	if resid <= tol {
+		return x, rhistory, nil
+	}
+	copy(rhat, r)
+
+	rho, alpha, omega := 1.0, 1.0, 1.0
+	for iter := 0; iter < maxiter; iter++ {
+		rhoNew := floats.Dot(rhat, r)
+		if rhoNew == 0.0 {
+			return nil, rhistory, Breakdown
+		}
+		beta := (rhoNew / rho) * (alpha / omega)
+		for i := range p {
+			p[i] = r[i] + beta*(p[i]-omega*v[i])
+		}
+
+		precondition(M, ph, p)
+		A.mulVec(v, ph)
+		rv := floats.Dot(rhat, v)
+		if rv == 0.0 {
+			return nil, rhistory, Breakdown
+		}
+		alpha = rhoNew / rv
+		for i := range s {
+			s[i] = r[i] - alpha*v[i]
+		}
+		if resid := floats.Norm(s, 2) / bnorm; resid <= tol {
+			floats.AddScaled(x, alpha, ph)
+			rhistory = append(rhistory, resid)
+			return x, rhistory, nil
+		}
+
+		precondition(M, sh, s)
+		A.mulVec(t, sh)
+		tt := floats.Dot(t, t)
+		if tt == 0.0 {
+			return nil, rhistory, Breakdown
+		}
+		omega = floats.Dot(t, s) / tt
+		for i := range x {
+			x[i] += alpha*ph[i] + omega*sh[i]
+			r[i] = s[i] - omega*t[i]
+		}
+
+		resid = floats.Norm(r, 2) / bnorm
+		rhistory = append(rhistory, resid)
+		if resid <= tol {
+			return x, rhistory, nil
+		}
+		if omega == 0.0 || math.IsNaN(resid) {
+			return nil, rhistory, Breakdown
+		}
+		rho = rhoNew
+	}
+
+	return nil, rhistory, Maxiter
+}
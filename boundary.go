@@ -0,0 +1,274 @@
+package golis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Konstantin8105/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Dirichlet is prescribed value of degree of freedom in linear system
+//
+//	A * x = b
+//
+// For example, prescribed displacement of node.
+type Dirichlet struct {
+	Index int     // index of degree of freedom
+	Value float64 // prescribed value
+}
+
+// constraints returns flags of prescribed degrees of freedom and
+// prescribed values. If constraints is not valid, then create panic.
+func constraints(size int, bc []Dirichlet) (fixed []bool, values []float64) {
+	var et errors.Tree
+	et.Name = "Check Dirichlet boundary conditions"
+	fixed = make([]bool, size)
+	values = make([]float64, size)
+	for _, d := range bc {
+		if d.Index < 0 || size <= d.Index {
+			et.Add(fmt.Errorf("Index is outside of matrix: %d of %d", d.Index, size))
+			continue
+		}
+		if math.IsNaN(d.Value) || math.IsInf(d.Value, 0) {
+			et.Add(fmt.Errorf("Value of index %d is not valid: %v", d.Index, d.Value))
+			continue
+		}
+		if fixed[d.Index] && values[d.Index] != d.Value {
+			et.Add(fmt.Errorf("Different values for index %d: %v != %v",
+				d.Index, values[d.Index], d.Value))
+			continue
+		}
+		fixed[d.Index] = true
+		values[d.Index] = d.Value
+	}
+	if et.IsError() {
+		panic(et)
+	}
+	return
+}
+
+// checkRHS is panic if b is not vertical vector with size
+func checkRHS(b mat.Matrix, size int) {
+	if r, c := b.Dims(); r != size || c != 1 {
+		panic(fmt.Errorf("Vector b is not valid: [%d,%d] != [%d,1]", r, c, size))
+	}
+}
+
+// ApplyDirichlet applies prescribed values of degrees of freedom
+// to linear system A * x = b in one pass over matrix elements:
+//
+//	b[r]   -= A[r,i] * value, for all free rows r
+//	A[i,j]  = A[j,i] = 0,     for all j != i
+//	b[i]    = A[i,i] * value
+//
+// If diagonal element A[i,i] is zero, then 1 is used.
+// Matrix must be square. If b or constraints are not valid,
+// then create panic.
+func (m *SparseMatrix) ApplyDirichlet(b mat.Mutable, bc []Dirichlet) {
+	m.checkSquare()
+	m.applyDirichlet(b, bc, false)
+}
+
+// ApplyDirichlet applies prescribed values of degrees of freedom
+// to linear system A * x = b. Matrix is still symmetric.
+// See SparseMatrix.ApplyDirichlet.
+func (m *SparseMatrixSymmetric) ApplyDirichlet(b mat.Mutable, bc []Dirichlet) {
+	m.s.applyDirichlet(b, bc, true)
+}
+
+// applyDirichlet applies prescribed values. If symmetric is true, then
+// triples is upper triangle of symmetric matrix.
+func (m *SparseMatrix) applyDirichlet(b mat.Mutable, bc []Dirichlet, symmetric bool) {
+	checkRHS(b, m.r)
+	fixed, values := constraints(m.r, bc)
+	m.compress()
+
+	diag := make([]float64, m.r)
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		d := m.data.ts[i].d
+		if r == c {
+			diag[r] = d
+			continue
+		}
+		if !fixed[r] && fixed[c] {
+			b.Set(r, 0, b.At(r, 0)-d*values[c])
+		}
+		if symmetric && fixed[r] && !fixed[c] {
+			// lower triangle element [c,r]
+			b.Set(c, 0, b.At(c, 0)-d*values[r])
+		}
+		if fixed[r] || fixed[c] {
+			m.data.ts[i].d = 0.0
+		}
+	}
+
+	for i := range fixed {
+		if !fixed[i] {
+			continue
+		}
+		if diag[i] == 0.0 {
+			diag[i] = 1.0
+			m.data.ts = append(m.data.ts, triple{
+				position: int64(i) + int64(i)*int64(m.r),
+				d:        diag[i],
+			})
+		}
+		b.Set(i, 0, diag[i]*values[i])
+	}
+	m.data.amountAdded = -1
+	m.compress()
+}
+
+// ApplyPenalty applies prescribed values of degrees of freedom
+// to linear system A * x = b by penalty method:
+//
+//	A[i,i] += penalty
+//	b[i]   += penalty * value
+//
+// Penalty must be much more then diagonal elements of matrix,
+// for example 1e8 * max(|A[i,i]|). Matrix must be square.
+// If b, penalty or constraints are not valid, then create panic.
+func (m *SparseMatrix) ApplyPenalty(b mat.Mutable, bc []Dirichlet, penalty float64) {
+	m.checkSquare()
+	m.applyPenalty(b, bc, penalty)
+}
+
+// ApplyPenalty applies prescribed values of degrees of freedom
+// to linear system A * x = b by penalty method.
+// See SparseMatrix.ApplyPenalty.
+func (m *SparseMatrixSymmetric) ApplyPenalty(b mat.Mutable, bc []Dirichlet, penalty float64) {
+	m.s.applyPenalty(b, bc, penalty)
+}
+
+func (m *SparseMatrix) applyPenalty(b mat.Mutable, bc []Dirichlet, penalty float64) {
+	checkRHS(b, m.r)
+	checkValue(penalty)
+	if penalty <= 0 {
+		panic(fmt.Errorf("Penalty is not valid: %v", penalty))
+	}
+	fixed, values := constraints(m.r, bc)
+	for i := range fixed {
+		if !fixed[i] {
+			continue
+		}
+		m.data.ts = m.appendTriple(m.data.ts, triple{
+			position: int64(i) + int64(i)*int64(m.r),
+			d:        penalty,
+		})
+		m.data.amountAdded++
+		b.Set(i, 0, b.At(i, 0)+penalty*values[i])
+	}
+	m.compress()
+}
+
+// Elimination is mapping of reduced linear system after elimination
+// of prescribed degrees of freedom to original linear system.
+type Elimination struct {
+	free   []int     // original indexes of free degrees of freedom
+	values []float64 // prescribed values in original indexes
+}
+
+// Free returns original indexes of free degrees of freedom.
+// Index i of reduced system is correspond to original index Free()[i].
+func (e Elimination) Free() []int {
+	free := make([]int, len(e.free))
+	copy(free, e.free)
+	return free
+}
+
+// Restore returns solution of original linear system with prescribed
+// values based on solution x of reduced linear system.
+func (e Elimination) Restore(x mat.Matrix) *mat.Dense {
+	r, c := x.Dims()
+	if r != len(e.free) {
+		panic(fmt.Errorf("Size of reduced solution is not same: %d != %d", r, len(e.free)))
+	}
+	out := mat.NewDense(len(e.values), c, nil)
+	for i, v := range e.values {
+		for j := 0; j < c; j++ {
+			out.Set(i, j, v)
+		}
+	}
+	for i, f := range e.free {
+		for j := 0; j < c; j++ {
+			out.Set(f, j, x.At(i, j))
+		}
+	}
+	return out
+}
+
+// Eliminate returns reduced linear system without rows and columns
+// of prescribed degrees of freedom and mapping to original system.
+// Right-hand vector of reduced system is
+//
+//	b'[r] = b[r] - sum(A[r,i] * value)
+//
+// Matrix is not changed and must be square. If b or constraints are
+// not valid or all degrees of freedom are prescribed, then create panic.
+func (m *SparseMatrix) Eliminate(b mat.Matrix, bc []Dirichlet) (
+	*SparseMatrix, *mat.Dense, Elimination) {
+	m.checkSquare()
+	return m.eliminate(b, bc, false)
+}
+
+// Eliminate returns reduced symmetric linear system without rows and
+// columns of prescribed degrees of freedom and mapping to original
+// system. See SparseMatrix.Eliminate.
+func (m *SparseMatrixSymmetric) Eliminate(b mat.Matrix, bc []Dirichlet) (
+	*SparseMatrixSymmetric, *mat.Dense, Elimination) {
+	s, rb, e := m.s.eliminate(b, bc, true)
+	return &SparseMatrixSymmetric{s: s}, rb, e
+}
+
+func (m *SparseMatrix) eliminate(b mat.Matrix, bc []Dirichlet, symmetric bool) (
+	*SparseMatrix, *mat.Dense, Elimination) {
+	checkRHS(b, m.r)
+	fixed, values := constraints(m.r, bc)
+
+	// indexes of reduced system
+	e := Elimination{values: values}
+	index := make([]int, m.r)
+	for i := range fixed {
+		if fixed[i] {
+			index[i] = -1
+			continue
+		}
+		index[i] = len(e.free)
+		e.free = append(e.free, i)
+	}
+	size := len(e.free)
+	if size == 0 {
+		panic(fmt.Errorf("All degrees of freedom are prescribed"))
+	}
+
+	rb := mat.NewDense(size, 1, nil)
+	for i, f := range e.free {
+		rb.Set(i, 0, b.At(f, 0))
+	}
+
+	m.compress()
+	out := NewSparseMatrix(size, size)
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		d := m.data.ts[i].d
+		switch {
+		case !fixed[r] && !fixed[c]:
+			// order of triples is same, because indexes are
+			// changed monotonically
+			out.data.ts = append(out.data.ts, triple{
+				position: int64(index[r]) + int64(index[c])*int64(size),
+				d:        d,
+			})
+		case !fixed[r] && fixed[c]:
+			rb.Set(index[r], 0, rb.At(index[r], 0)-d*values[c])
+		case symmetric && fixed[r] && !fixed[c]:
+			// lower triangle element [c,r]
+			rb.Set(index[c], 0, rb.At(index[c], 0)-d*values[r])
+		}
+	}
+	return out, rb, e
+}
@@ -0,0 +1,171 @@
+package golis_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+// dirichletSystem returns right-hand vector b = A * x for exact
+// solution x and constraints with values of exact solution
+func dirichletSystem(A mat.Matrix) (x, b *mat.Dense, bc []golis.Dirichlet) {
+	size, _ := A.Dims()
+	x = mat.NewDense(size, 1, nil)
+	for i := 0; i < size; i++ {
+		x.Set(i, 0, math.Sin(float64(i)))
+	}
+	b = mat.NewDense(size, 1, nil)
+	b.Mul(A, x)
+	bc = []golis.Dirichlet{
+		{Index: 0, Value: x.At(0, 0)},
+		{Index: size / 2, Value: x.At(size/2, 0)},
+		{Index: size - 1, Value: x.At(size-1, 0)},
+		{Index: size - 1, Value: x.At(size-1, 0)}, // same constraint
+	}
+	return
+}
+
+// solveDense returns solution of linear system by dense solver
+func solveDense(t *testing.T, A, b mat.Matrix) *mat.Dense {
+	var x mat.Dense
+	if err := x.Solve(mat.DenseCopyOf(A), b); err != nil {
+		t.Fatal(err)
+	}
+	return &x
+}
+
+// dirichletMatrix is matrix with boundary conditions methods
+type dirichletMatrix interface {
+	mat.Matrix
+	ApplyDirichlet(b mat.Mutable, bc []golis.Dirichlet)
+	ApplyPenalty(b mat.Mutable, bc []golis.Dirichlet, penalty float64)
+}
+
+func TestDirichlet(t *testing.T) {
+	size := 10
+	for _, tc := range []struct {
+		name string
+		A    func() dirichletMatrix
+	}{
+		{
+			name: "General",
+			A:    func() dirichletMatrix { return convectionDiffusion(size) },
+		},
+		{
+			name: "Symmetric",
+			A:    func() dirichletMatrix { return laplacian(size) },
+		},
+	} {
+		t.Run(tc.name+"Dirichlet", func(t *testing.T) {
+			A := tc.A()
+			x, b, bc := dirichletSystem(A)
+			A.ApplyDirichlet(b, bc)
+			for _, d := range bc {
+				for j := 0; j < size; j++ {
+					if j != d.Index && (A.At(d.Index, j) != 0 || A.At(j, d.Index) != 0) {
+						t.Fatalf("Not zero row or column %d", d.Index)
+					}
+				}
+			}
+			if !mat.EqualApprox(solveDense(t, A, b), x, 1e-12) {
+				t.Fatalf("Not valid solution")
+			}
+		})
+		t.Run(tc.name+"Penalty", func(t *testing.T) {
+			A := tc.A()
+			x, b, bc := dirichletSystem(A)
+			A.ApplyPenalty(b, bc, 1e10)
+			if !mat.EqualApprox(solveDense(t, A, b), x, 1e-8) {
+				t.Fatalf("Not valid solution")
+			}
+		})
+	}
+
+	t.Run("ZeroDiagonal", func(t *testing.T) {
+		A := golis.NewSparseMatrix(3, 3)
+		A.Add(0, 1, 2.0)
+		A.Add(1, 0, 2.0)
+		A.Add(1, 1, 3.0)
+		A.Add(2, 2, 1.0)
+		b := mat.NewDense(3, 1, []float64{4, 12, 1})
+		A.ApplyDirichlet(b, []golis.Dirichlet{{Index: 0, Value: 3}})
+		if v := A.At(0, 0); v != 1.0 {
+			t.Fatalf("Not valid diagonal: %v", v)
+		}
+		x := solveDense(t, A, b)
+		if !mat.Equal(x, mat.NewDense(3, 1, []float64{3, 2, 1})) {
+			t.Fatalf("Not valid solution: %v", mat.Formatted(x))
+		}
+	})
+}
+
+func TestEliminate(t *testing.T) {
+	size := 10
+	check := func(t *testing.T, x, rx *mat.Dense, e golis.Elimination) {
+		free := e.Free()
+		if r, _ := rx.Dims(); r != size-3 || len(free) != r {
+			t.Fatalf("Not valid size of reduced system: %d", r)
+		}
+		if !mat.EqualApprox(e.Restore(rx), x, 1e-12) {
+			t.Fatalf("Not valid solution")
+		}
+	}
+
+	t.Run("General", func(t *testing.T) {
+		A := convectionDiffusion(size)
+		x, b, bc := dirichletSystem(A)
+		before := A.String()
+		ra, rb, e := A.Eliminate(b, bc)
+		if A.String() != before {
+			t.Fatalf("Matrix is changed")
+		}
+		check(t, x, solveDense(t, ra, rb), e)
+	})
+	t.Run("Symmetric", func(t *testing.T) {
+		A := laplacian(size)
+		x, b, bc := dirichletSystem(A)
+		ra, rb, e := A.Eliminate(b, bc)
+		rx, _, _, err := golis.LsolveNative(ra, rb, "-i cg")
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(t, x, mat.DenseCopyOf(rx), e)
+	})
+}
+
+func TestDirichletPanics(t *testing.T) {
+	b := mat.NewDense(3, 1, nil)
+	for i, f := range []func(){
+		func() { golis.NewSparseMatrix(3, 3).ApplyDirichlet(b, []golis.Dirichlet{{Index: 3}}) },
+		func() {
+			golis.NewSparseMatrix(3, 3).ApplyDirichlet(b, []golis.Dirichlet{{Index: 0, Value: math.NaN()}})
+		},
+		func() {
+			golis.NewSparseMatrixSymmetric(3).ApplyDirichlet(b, []golis.Dirichlet{{Index: 0, Value: 1}, {Index: 0, Value: 2}})
+		},
+		func() { golis.NewSparseMatrix(2, 2).ApplyDirichlet(b, nil) },
+		func() { golis.NewSparseMatrix(3, 2).ApplyDirichlet(b, nil) },
+		func() { golis.NewSparseMatrix(3, 3).ApplyPenalty(b, nil, 0) },
+		func() {
+			golis.NewSparseMatrix(1, 1).Eliminate(mat.NewDense(1, 1, nil), []golis.Dirichlet{{Index: 0}})
+		},
+		func() {
+			var e golis.Elimination
+			e.Restore(b)
+		},
+	} {
+		t.Run(fmt.Sprintf("Panic%d", i), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				t.Logf("\n%v", r)
+				if r == nil {
+					t.Fatal("Haven`t panic for not valid data")
+				}
+			}()
+			f()
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package golis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Konstantin8105/errors"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// CG returns solution matrix of Conjugate Gradient iterative solve for
+// linear system without external `lis` software.
+//
+//	A * x = b
+//
+// Where: A is symmetric positive-definite matrix, b is right-hand vector.
+//
+// Iterations are finished, if relative residual norm ||b-A*x||/||b||
+// is less or equal tol. Solution and residual history have same
+// format as result of function Lsolve.
+// If solution is not found after maxiter iterations, then error Maxiter
+// is returned.
+func CG(A *SparseMatrixSymmetric, b mat.Matrix, tol float64, maxiter int) (
+	solution mat.Matrix,
+	rhistory []float64,
+	err error) {
+
+	if err = checkSystem(A, b); err != nil {
+		return
+	}
+	if err = checkIterative(tol, maxiter); err != nil {
+		return
+	}
+
+	x, rhistory, err := cg(A, vectorFromMatrix(b), nil, nil, tol, maxiter)
+	if err != nil {
+		return
+	}
+	n, _ := b.Dims()
+	solution = mat.NewDense(n, 1, x)
+	return
+}
+
+// cg is preconditioned Conjugate Gradient method.
+// If x0 is nil, then initial guess is zero.
+// If M is nil, then method is without preconditioner.
+func cg(A matVec, b, x0 []float64, M Preconditioner, tol float64, maxiter int) (
+	x []float64,
+	rhistory []float64,
+	err error) {
+
+	n := len(b)
+	var (
+		r  = make([]float64, n)
+		z  = make([]float64, n)
+		p  = make([]float64, n)
+		ap = make([]float64, n)
+	)
+	bnorm := floats.Norm(b, 2)
+	if bnorm == 0.0 {
+		// trivial solution
+		return make([]float64, n), []float64{0.0}, nil
+	}
+	x = initial(A, b, x0, r)
+	resid := floats.Norm(r, 2) / bnorm
+	rhistory = append(rhistory, resid)
+	if resid <= tol {
+		return x, rhistory, nil
+	}
+	precondition(M, z, r)
+	copy(p, z)
+	rz := floats.Dot(r, z)
+
+	for iter := 0; iter < maxiter; iter++ {
+		A.mulVec(ap, p)
+		pap := floats.Dot(p, ap)
+		if pap == 0.0 {
+			return nil, rhistory, Breakdown
+		}
+		alpha := rz / pap
+		floats.AddScaled(x, alpha, p)
+		floats.AddScaled(r, -alpha, ap)
+
+		resid = floats.Norm(r, 2) / bnorm
+		rhistory = append(rhistory, resid)
+		if resid <= tol {
+			return x, rhistory, nil
+		}
+		if math.IsNaN(resid) {
+			return nil, rhistory, Breakdown
+		}
+
+		precondition(M, z, r)
+		rzNew := floats.Dot(r, z)
+		beta := rzNew / rz
+		rz = rzNew
+		for i := range p {
+			p[i] = z[i] + beta*p[i]
+		}
+	}
+
+	return nil, rhistory, Maxiter
+}
+
+// checkIterative returns error, if parameters of iterative solver
+// are not valid
+func checkIterative(tol float64, maxiter int) error {
+	var et errors.Tree
+	et.Name = "Check parameters of iterative solver"
+	if !(tol > 0.0) || math.IsInf(tol, 0) {
+		et.Add(fmt.Errorf("Tolerance is not valid: %v", tol))
+	}
+	if maxiter <= 0 {
+		et.Add(fmt.Errorf("Amount of iterations is not valid: %d", maxiter))
+	}
+	if et.IsError() {
+		return et
+	}
+	return nil
+}
+
+// initial returns initial guess x and calculate residual r = b - A * x.
+// If x0 is nil, then initial guess is zero.
+func initial(A matVec, b, x0, r []float64) (x []float64) {
+	x = make([]float64, len(b))
+	if x0 == nil {
+		copy(r, b)
+		return
+	}
+	copy(x, x0)
+	A.mulVec(r, x)
+	floats.SubTo(r, b, r)
+	return
+}
+
+// vectorFromMatrix returns copy of first column of matrix
+func vectorFromMatrix(b mat.Matrix) []float64 {
+	n, _ := b.Dims()
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = b.At(i, 0)
+	}
+	return v
+}
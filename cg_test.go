@@ -0,0 +1,108 @@
+package golis_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+// laplacian returns symmetric positive-definite sparse matrix
+// of one-dimensional Laplace operator
+func laplacian(size int) *golis.SparseMatrixSymmetric {
+	A := golis.NewSparseMatrixSymmetric(size)
+	for i := 0; i < size; i++ {
+		A.Add(i, i, 2.0)
+		if i+1 < size {
+			A.Add(i, i+1, -1.0)
+		}
+	}
+	return A
+}
+
+// residual returns norm of vector b - A * x
+func residual(A, x, b mat.Matrix) float64 {
+	var ax mat.Dense
+	ax.Mul(A, x)
+	var r mat.Dense
+	r.Sub(b, &ax)
+	return mat.Norm(&r, 2)
+}
+
+func TestCG(t *testing.T) {
+	for _, size := range []int{1, 2, 5, 50} {
+		t.Run(fmt.Sprintf("Size%d", size), func(t *testing.T) {
+			A := laplacian(size)
+			b := mat.NewDense(size, 1, nil)
+			for i := 0; i < size; i++ {
+				b.Set(i, 0, float64(i+1))
+			}
+
+			s, rhistory, err := golis.CG(A, b, 1e-12, 1000)
+			if err != nil {
+				t.Fatalf("Not correct result: %v", err)
+			}
+			if r := residual(A, s, b); r > 1e-8 {
+				t.Errorf("Residual is too big: %v", r)
+			}
+			if len(rhistory) < 2 || rhistory[0] != 1.0 {
+				t.Errorf("Not correct residual history: %v", rhistory)
+			}
+		})
+	}
+
+	t.Run("ZeroVector", func(t *testing.T) {
+		A := laplacian(3)
+		b := mat.NewDense(3, 1, nil)
+		s, _, err := golis.CG(A, b, 1e-12, 1000)
+		if err != nil {
+			t.Fatalf("Not correct result: %v", err)
+		}
+		if mat.Norm(s, 2) != 0.0 {
+			t.Errorf("Solution is not zero: %v", mat.Formatted(s))
+		}
+	})
+}
+
+func TestCGFail(t *testing.T) {
+	A := laplacian(100)
+	b := mat.NewDense(100, 1, nil)
+	for i := 0; i < 100; i++ {
+		b.Set(i, 0, math.Sin(float64(i)))
+	}
+
+	t.Run("Maxiter", func(t *testing.T) {
+		_, _, err := golis.CG(A, b, 1e-12, 2)
+		if err != golis.Maxiter {
+			t.Fatalf("Not correct error: %v", err)
+		}
+	})
+
+	for i, tc := range []struct {
+		tol     float64
+		maxiter int
+	}{
+		{0.0, 10},
+		{-1.0, 10},
+		{math.NaN(), 10},
+		{1e-10, 0},
+	} {
+		t.Run(fmt.Sprintf("Parameters%d", i), func(t *testing.T) {
+			_, _, err := golis.CG(A, b, tc.tol, tc.maxiter)
+			t.Logf("\n%v", err)
+			if err == nil {
+				t.Fatalf("Haven`t error : %v", err)
+			}
+		})
+	}
+
+	t.Run("Size", func(t *testing.T) {
+		_, _, err := golis.CG(A, mat.NewDense(3, 1, nil), 1e-10, 10)
+		t.Logf("\n%v", err)
+		if err == nil {
+			t.Fatalf("Haven`t error : %v", err)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package golis
+
+import (
+	"runtime"
+	"sync"
+)
+
+// CompressParallelThreshold is minimal amount of unsorted triples for
+// parallel sorting and summation of duplicates in sparse matrix
+// compression.
+// Zero or negative value is disable parallel compression.
+var CompressParallelThreshold = 1 << 16
+
+// compressWorkers returns amount of goroutines for compression
+// with n unsorted triples
+func compressWorkers(n int) int {
+	if CompressParallelThreshold <= 0 || n < CompressParallelThreshold {
+		return 1
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n/minCompressChunk {
+		workers = n / minCompressChunk
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
+// minCompressChunk is minimal amount of triples in one chunk
+// of parallel compression
+const minCompressChunk = 1024
+
+// sortParallel sorts triples by position. Slice buf is used as
+// temporary storage with same size. Result is ts or buf.
+func sortParallel(ts, buf []triple, workers int) []triple {
+	// bounds of chunks
+	bounds := make([]int, workers+1)
+	for w := range bounds {
+		bounds[w] = w * len(ts) / workers
+	}
+
+	// sort each chunk with keeping order of same positions
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(part, buf []triple) {
+			defer wg.Done()
+			if sorted := stableSort(part, buf); len(part) > 0 && &sorted[0] != &part[0] {
+				copy(part, sorted)
+			}
+		}(ts[bounds[w]:bounds[w+1]], buf[bounds[w]:bounds[w+1]])
+	}
+	wg.Wait()
+
+	// merge pairs of neighbour chunks
+	for len(bounds) > 2 {
+		next := make([]int, 0, len(bounds)/2+1)
+		for w := 0; w+1 < len(bounds); w += 2 {
+			from := bounds[w]
+			if w+2 >= len(bounds) {
+				// odd chunk without pair
+				to := bounds[w+1]
+				copy(buf[from:to], ts[from:to])
+				next = append(next, from)
+				continue
+			}
+			mid, to := bounds[w+1], bounds[w+2]
+			wg.Add(1)
+			go func(left, right, out []triple) {
+				defer wg.Done()
+				mergeTriples(left, right, out)
+			}(ts[from:mid], ts[mid:to], buf[from:to])
+			next = append(next, from)
+		}
+		next = append(next, len(ts))
+		wg.Wait()
+		bounds = next
+		ts, buf = buf, ts
+	}
+	return ts
+}
+
+// mergeTriples merges sorted slices left and right into out
+func mergeTriples(left, right, out []triple) {
+	var i, j, k int
+	for i < len(left) && j < len(right) {
+		if right[j].position < left[i].position {
+			out[k] = right[j]
+			j++
+		} else {
+			out[k] = left[i]
+			i++
+		}
+		k++
+	}
+	k += copy(out[k:], left[i:])
+	copy(out[k:], right[j:])
+}
+
+// sumParallel summarizes sorted triples with same position and
+// removes triples with zero values. Triples with same position
+// are always in one chunk.
+func sumParallel(ts []triple, workers int) []triple {
+	// bounds of chunks without splitting of same positions
+	bounds := make([]int, 0, workers+1)
+	bounds = append(bounds, 0)
+	for w := 1; w < workers; w++ {
+		b := w * len(ts) / workers
+		if b < bounds[len(bounds)-1] {
+			b = bounds[len(bounds)-1]
+		}
+		for b > 0 && b < len(ts) && ts[b-1].position == ts[b].position {
+			b++
+		}
+		bounds = append(bounds, b)
+	}
+	bounds = append(bounds, len(ts))
+
+	// summarize and compact each chunk in place
+	sizes := make([]int, len(bounds)-1)
+	var wg sync.WaitGroup
+	for w := range sizes {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			sizes[w] = sumTriples(ts[bounds[w]:bounds[w+1]])
+		}(w)
+	}
+	wg.Wait()
+
+	// move chunks together
+	size := sizes[0]
+	for w := 1; w < len(sizes); w++ {
+		size += copy(ts[size:], ts[bounds[w]:bounds[w]+sizes[w]])
+	}
+	return ts[:size]
+}
+
+// sumTriples summarizes sorted triples with same position, removes
+// triples with zero values and returns amount of triples in result
+func sumTriples(ts []triple) (size int) {
+	for i := 0; i < len(ts); {
+		t := ts[i]
+		for i++; i < len(ts) && ts[i].position == t.position; i++ {
+			t.d += ts[i].d
+		}
+		if t.d == 0.0 {
+			continue
+		}
+		ts[size] = t
+		size++
+	}
+	return
+}
@@ -0,0 +1,213 @@
+package golis_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+)
+
+// randomTriples adds amount random values with many duplicates and
+// values with zero summary in matrix
+func randomTriples(m *golis.SparseMatrix, size, amount int) {
+	rnd := rand.New(rand.NewSource(42))
+	a := m.Assembler()
+	for i := 0; i < amount; i++ {
+		r, c := rnd.Intn(size), rnd.Intn(size)
+		v := float64(rnd.Intn(20) - 10)
+		if v == 0 {
+			v = 1
+		}
+		a.Add(r, c, v)
+		if i%7 == 0 {
+			a.Add(r, c, -v)
+		}
+	}
+	m.Merge(a)
+}
+
+// nonZeros returns string with all non-zero elements of matrix
+func nonZeros(m *golis.SparseMatrix) string {
+	var buf bytes.Buffer
+	m.DoNonZero(func(r, c int, v float64) {
+		fmt.Fprintf(&buf, "%d %d %v\n", r, c, v)
+	})
+	return buf.String()
+}
+
+func TestCompressParallel(t *testing.T) {
+	threshold := golis.CompressParallelThreshold
+	procs := runtime.GOMAXPROCS(8)
+	defer func() {
+		golis.CompressParallelThreshold = threshold
+		runtime.GOMAXPROCS(procs)
+	}()
+
+	for _, tc := range []struct {
+		size, amount int
+	}{
+		{size: 1, amount: 5000},
+		{size: 10, amount: 5000},
+		{size: 100, amount: 20000},
+		{size: 3000, amount: 50000},
+		{size: 100000, amount: 300000},
+	} {
+		t.Run(fmt.Sprintf("%dx%d", tc.size, tc.amount), func(t *testing.T) {
+			golis.CompressParallelThreshold = 0
+			serial := golis.NewSparseMatrix(tc.size, tc.size)
+			randomTriples(serial, tc.size, tc.amount)
+
+			golis.CompressParallelThreshold = 1
+			parallel := golis.NewSparseMatrix(tc.size, tc.size)
+			randomTriples(parallel, tc.size, tc.amount)
+
+			if a, b := nonZeros(serial), nonZeros(parallel); a != b {
+				t.Fatalf("Not same result of compression:\n%s\n%s", a, b)
+			}
+		})
+	}
+}
+
+// triple is copy of matrix element in sparse matrix
+type triple struct {
+	position int64   // position matrix element (row + column * size)
+	d        float64 // data
+}
+
+// byTriple implements sort.Interface based on the position field.
+type byTriple []triple
+
+func (a byTriple) Len() int           { return len(a) }
+func (a byTriple) Less(i, j int) bool { return a[i].position < a[j].position }
+func (a byTriple) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// compressBaseline is compression of triples as it was before parallel
+// compression: sort.Sort of all triples and summation of duplicates
+func compressBaseline(ts []triple) []triple {
+	// sort by position
+	sort.Sort(byTriple(ts))
+
+	// summarize element with same indexes row, column and add 0.0 in old element
+	for i := 1; i < len(ts); i++ {
+		if ts[i-1].position != ts[i].position {
+			continue
+		}
+		nonZero := i - 1
+		for ; i < len(ts); i++ {
+			if ts[nonZero].position != ts[i].position {
+				break
+			}
+			// triples element i-1 and i have same row and column
+			ts[nonZero].d += ts[i].d
+			ts[i].d = 0.0
+		}
+	}
+
+	// moving data for avoid elements with 0.0 values
+	var nonZeroPos int
+	for zeroPos := 0; zeroPos < len(ts); zeroPos++ {
+		// find position of zero value triple
+		if math.Abs(ts[zeroPos].d) != 0.0 {
+			continue
+		}
+
+		// find next non-zero value triple
+		if nonZeroPos < zeroPos {
+			nonZeroPos = zeroPos
+		}
+		for ; nonZeroPos < len(ts); nonZeroPos++ {
+			if math.Abs(ts[nonZeroPos].d) != 0.0 {
+				break
+			}
+		}
+		if nonZeroPos >= len(ts) {
+			break
+		}
+
+		// move value
+		ts[zeroPos] = ts[nonZeroPos]
+		ts[nonZeroPos].d = 0.0
+	}
+
+	// cut triple slice by nonzero elements
+	var cut int
+	for cut = len(ts) - 1; cut >= 0; cut-- {
+		if math.Abs(ts[cut].d) != 0.0 {
+			break
+		}
+	}
+	return ts[:cut+1]
+}
+
+func BenchmarkCompress(b *testing.B) {
+	threshold := golis.CompressParallelThreshold
+	defer func() {
+		golis.CompressParallelThreshold = threshold
+	}()
+	size := 20000
+	amount := 1000000
+	// compression before parallel compression with same triples
+	b.Run("Baseline", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			ts := make([]triple, amount)
+			rnd := rand.New(rand.NewSource(int64(i)))
+			for j := range ts {
+				r, c := rnd.Intn(size), rnd.Intn(size)
+				ts[j] = triple{position: int64(r) + int64(c)*int64(size), d: 1.0}
+			}
+			b.StartTimer()
+			_ = compressBaseline(ts)
+		}
+	})
+	for _, bc := range []struct {
+		name      string
+		threshold int
+	}{
+		{name: "Serial", threshold: 0},
+		{name: "Parallel", threshold: 1},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			golis.CompressParallelThreshold = bc.threshold
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				m := golis.NewSparseMatrix(size, size)
+				as := m.Assembler()
+				rnd := rand.New(rand.NewSource(int64(i)))
+				for j := 0; j < amount; j++ {
+					as.Add(rnd.Intn(size), rnd.Intn(size), 1.0)
+				}
+				b.StartTimer()
+				m.Merge(as)
+			}
+		})
+	}
+	// repeated compressions of large matrix by method Add
+	for _, bc := range []struct {
+		name      string
+		threshold int
+	}{
+		{name: "AddSerial", threshold: 0},
+		{name: "AddParallel", threshold: 1},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			golis.CompressParallelThreshold = bc.threshold
+			m := golis.NewSparseMatrix(size, size)
+			randomTriples(m, size, amount)
+			rnd := rand.New(rand.NewSource(42))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// each cycle of size+1 additions is finished by compression
+				for j := 0; j <= size; j++ {
+					m.Add(rnd.Intn(size), rnd.Intn(size), 1.0)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package golis
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// guarantee CSR and CSC have interface of gonum.mat.Matrix
+var (
+	_ mat.Matrix = (*CSR)(nil)
+	_ mat.Matrix = (*CSC)(nil)
+)
+
+// compressed is immutable storage of compressed sparse vectors.
+// Indexes and values of vector i are stored in
+// ind[ptr[i]:ptr[i+1]] and data[ptr[i]:ptr[i+1]].
+type compressed struct {
+	ptr  []int     // pointers of vectors, length is amount of vectors + 1
+	ind  []int     // sorted indexes of non-zero elements in vector
+	data []float64 // values of non-zero elements
+}
+
+// vector returns indexes and values of vector i
+func (cs compressed) vector(i int) (ind []int, data []float64) {
+	return cs.ind[cs.ptr[i]:cs.ptr[i+1]], cs.data[cs.ptr[i]:cs.ptr[i+1]]
+}
+
+// at returns value of element with index j in vector i
+func (cs compressed) at(i, j int) float64 {
+	ind, data := cs.vector(i)
+	index := sort.SearchInts(ind, j)
+	if index < len(ind) && ind[index] == j {
+		return data[index]
+	}
+	return 0.0
+}
+
+// CSR is immutable sparse matrix in Compressed Sparse Row format
+type CSR struct {
+	r, c int
+	cs   compressed // rows
+}
+
+// CSC is immutable sparse matrix in Compressed Sparse Column format
+type CSC struct {
+	r, c int
+	cs   compressed // columns
+}
+
+// NewCSR returns matrix in Compressed Sparse Row format
+// based on slices without copy. Column indexes in each row must be sorted.
+//
+// Where: indptr is pointers of rows with length r+1, ind is column
+// indexes and data is values of non-zero elements.
+func NewCSR(r, c int, indptr, ind []int, data []float64) *CSR {
+	checkCompressed(r, c, indptr, ind, data)
+	return &CSR{r: r, c: c, cs: compressed{ptr: indptr, ind: ind, data: data}}
+}
+
+// NewCSC returns matrix in Compressed Sparse Column format
+// based on slices without copy. Row indexes in each column must be sorted.
+//
+// Where: indptr is pointers of columns with length c+1, ind is row
+// indexes and data is values of non-zero elements.
+func NewCSC(r, c int, indptr, ind []int, data []float64) *CSC {
+	checkCompressed(c, r, indptr, ind, data)
+	return &CSC{r: r, c: c, cs: compressed{ptr: indptr, ind: ind, data: data}}
+}
+
+// checkCompressed is panic if compressed data is not valid
+func checkCompressed(vectors, size int, indptr, ind []int, data []float64) {
+	if vectors <= 0 || size <= 0 {
+		panic(fmt.Errorf("Not valid sizes of matrix: %d, %d", vectors, size))
+	}
+	if len(indptr) != vectors+1 || indptr[0] != 0 {
+		panic(fmt.Errorf("Not valid pointers"))
+	}
+	if len(ind) != len(data) || indptr[vectors] != len(ind) {
+		panic(fmt.Errorf("Not valid amount of non-zero elements: %d, %d, %d",
+			indptr[vectors], len(ind), len(data)))
+	}
+	for i := 0; i < vectors; i++ {
+		if indptr[i] > indptr[i+1] {
+			panic(fmt.Errorf("Pointers are not sorted: %d", i))
+		}
+		for k := indptr[i]; k < indptr[i+1]; k++ {
+			if ind[k] < 0 || size <= ind[k] {
+				panic(fmt.Errorf("Index is outside of matrix: %d", ind[k]))
+			}
+			if k > indptr[i] && ind[k-1] >= ind[k] {
+				panic(fmt.Errorf("Indexes are not sorted: %d", i))
+			}
+		}
+	}
+}
+
+// CSC returns matrix in Compressed Sparse Column format.
+// Triples of sparse matrix are stored by columns, so conversion
+// is linear.
+func (m *SparseMatrix) CSC() *CSC {
+	m.compress()
+	out := &CSC{r: m.r, c: m.c}
+	out.cs.ptr = make([]int, m.c+1)
+	out.cs.ind = make([]int, len(m.data.ts))
+	out.cs.data = make([]float64, len(m.data.ts))
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		out.cs.ptr[c+1]++
+		out.cs.ind[i] = r
+		out.cs.data[i] = m.data.ts[i].d
+	}
+	for c := 0; c < m.c; c++ {
+		out.cs.ptr[c+1] += out.cs.ptr[c]
+	}
+	return out
+}
+
+// CSR returns matrix in Compressed Sparse Row format.
+// Conversion is linear by counting of elements in rows.
+func (m *SparseMatrix) CSR() *CSR {
+	m.compress()
+	out := &CSR{r: m.r, c: m.c}
+	out.cs.ptr = make([]int, m.r+1)
+	out.cs.ind = make([]int, len(m.data.ts))
+	out.cs.data = make([]float64, len(m.data.ts))
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		out.cs.ptr[r+1]++
+	}
+	for r := 0; r < m.r; r++ {
+		out.cs.ptr[r+1] += out.cs.ptr[r]
+	}
+	// triples is sorted by columns, so columns in rows are sorted
+	next := make([]int, m.r)
+	copy(next, out.cs.ptr)
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		out.cs.ind[next[r]] = c
+		out.cs.data[next[r]] = m.data.ts[i].d
+		next[r]++
+	}
+	return out
+}
+
+// CSR returns full symmetric matrix in Compressed Sparse Row format.
+func (m *SparseMatrixSymmetric) CSR() *CSR {
+	full := m.full()
+	return full.CSR()
+}
+
+// CSC returns full symmetric matrix in Compressed Sparse Column format.
+func (m *SparseMatrixSymmetric) CSC() *CSC {
+	full := m.full()
+	return full.CSC()
+}
+
+// full returns sparse matrix with upper and lower triangles
+func (m *SparseMatrixSymmetric) full() *SparseMatrix {
+	m.s.compress()
+	out := NewSparseMatrix(m.s.r, m.s.c)
+	out.data.ts = make([]triple, 0, 2*len(m.s.data.ts))
+	for i := range m.s.data.ts {
+		out.data.ts = append(out.data.ts, m.s.data.ts[i])
+		r := m.s.data.ts[i].position % int64(m.s.r)
+		c := m.s.data.ts[i].position / int64(m.s.r)
+		if r != c {
+			out.data.ts = append(out.data.ts, triple{
+				position: c + r*int64(m.s.r),
+				d:        m.s.data.ts[i].d,
+			})
+		}
+	}
+	out.data.amountAdded = -1
+	out.compress()
+	return out
+}
+
+// At returns the value of a matrix element at row i, column j.
+// It will panic if i or j are out of bounds for the matrix.
+func (m *CSR) At(r, c int) float64 {
+	checkIndexes(r, c, m.r, m.c)
+	return m.cs.at(r, c)
+}
+
+// Dims returns the dimensions of a Matrix.
+// Where: r - amount of rows, c - amount of columns.
+func (m *CSR) Dims() (r, c int) {
+	return m.r, m.c
+}
+
+// T returns the transpose of the Matrix in CSC format without copy
+// of data.
+func (m *CSR) T() mat.Matrix {
+	return &CSC{r: m.c, c: m.r, cs: m.cs}
+}
+
+// Row returns column indexes and values of non-zero elements in row r.
+// Slices are not copy of matrix data and must not be changed.
+func (m *CSR) Row(r int) (ind []int, data []float64) {
+	checkIndexes(r, 0, m.r, m.c)
+	return m.cs.vector(r)
+}
+
+// NNZ returns amount of stored non-zero elements.
+func (m *CSR) NNZ() int {
+	return len(m.cs.data)
+}
+
+// RawCSR returns slices of matrix data without copy.
+// See description of NewCSR.
+func (m *CSR) RawCSR() (indptr, ind []int, data []float64) {
+	return m.cs.ptr, m.cs.ind, m.cs.data
+}
+
+// mulVec calculate vector y = m * x
+func (m *CSR) mulVec(y, x []float64) {
+	for r := 0; r < m.r; r++ {
+		var sum float64
+		for k := m.cs.ptr[r]; k < m.cs.ptr[r+1]; k++ {
+			sum += m.cs.data[k] * x[m.cs.ind[k]]
+		}
+		y[r] = sum
+	}
+}
+
+// At returns the value of a matrix element at row i, column j.
+// It will panic if i or j are out of bounds for the matrix.
+func (m *CSC) At(r, c int) float64 {
+	checkIndexes(r, c, m.r, m.c)
+	return m.cs.at(c, r)
+}
+
+// Dims returns the dimensions of a Matrix.
+// Where: r - amount of rows, c - amount of columns.
+func (m *CSC) Dims() (r, c int) {
+	return m.r, m.c
+}
+
+// T returns the transpose of the Matrix in CSR format without copy
+// of data.
+func (m *CSC) T() mat.Matrix {
+	return &CSR{r: m.c, c: m.r, cs: m.cs}
+}
+
+// Col returns row indexes and values of non-zero elements in column c.
+// Slices are not copy of matrix data and must not be changed.
+func (m *CSC) Col(c int) (ind []int, data []float64) {
+	checkIndexes(0, c, m.r, m.c)
+	return m.cs.vector(c)
+}
+
+// NNZ returns amount of stored non-zero elements.
+func (m *CSC) NNZ() int {
+	return len(m.cs.data)
+}
+
+// RawCSC returns slices of matrix data without copy.
+// See description of NewCSC.
+func (m *CSC) RawCSC() (indptr, ind []int, data []float64) {
+	return m.cs.ptr, m.cs.ind, m.cs.data
+}
+
+// mulVec calculate vector y = m * x
+func (m *CSC) mulVec(y, x []float64) {
+	for i := range y {
+		y[i] = 0.0
+	}
+	for c := 0; c < m.c; c++ {
+		for k := m.cs.ptr[c]; k < m.cs.ptr[c+1]; k++ {
+			y[m.cs.ind[k]] += m.cs.data[k] * x[c]
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package golis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCSR(t *testing.T) {
+	a := mat.NewDense(3, 4, []float64{
+		8, 0, 6, 0,
+		0, 0, 0, 0,
+		4, 1, 0, 2,
+	})
+	s := golis.NewSparseMatrix(3, 4)
+	for i := 0; i < 3; i++ {
+		for j := 3; j >= 0; j-- {
+			s.Add(i, j, a.At(i, j))
+		}
+	}
+
+	t.Run("CSR", func(t *testing.T) {
+		csr := s.CSR()
+		if !isSame(csr, a) {
+			t.Fatalf("Value is not same:\n%v", mat.Formatted(csr))
+		}
+		if r, c := csr.Dims(); r != 3 || c != 4 || csr.NNZ() != 5 {
+			t.Fatalf("Not valid sizes: [%d,%d] %d", r, c, csr.NNZ())
+		}
+		indptr, ind, data := csr.RawCSR()
+		if fmt.Sprint(indptr, ind, data) != "[0 2 2 5] [0 2 0 1 3] [8 6 4 1 2]" {
+			t.Errorf("Not valid data: %v %v %v", indptr, ind, data)
+		}
+		ind, data = csr.Row(2)
+		if fmt.Sprint(ind, data) != "[0 1 3] [4 1 2]" {
+			t.Errorf("Not valid row: %v %v", ind, data)
+		}
+		if !isSame(csr.T(), a.T()) {
+			t.Fatalf("Transpose is not same")
+		}
+		indptr, ind, data = csr.RawCSR()
+		if !isSame(golis.NewCSR(3, 4, indptr, ind, data), a) {
+			t.Fatalf("Value is not same for NewCSR")
+		}
+	})
+
+	t.Run("CSC", func(t *testing.T) {
+		csc := s.CSC()
+		if !isSame(csc, a) {
+			t.Fatalf("Value is not same:\n%v", mat.Formatted(csc))
+		}
+		indptr, ind, data := csc.RawCSC()
+		if fmt.Sprint(indptr, ind, data) != "[0 2 3 4 5] [0 2 2 0 2] [8 4 1 6 2]" {
+			t.Errorf("Not valid data: %v %v %v", indptr, ind, data)
+		}
+		ind, data = csc.Col(0)
+		if fmt.Sprint(ind, data) != "[0 2] [8 4]" {
+			t.Errorf("Not valid column: %v %v", ind, data)
+		}
+		if csc.NNZ() != 5 || !isSame(csc.T(), a.T()) {
+			t.Fatalf("Transpose is not same")
+		}
+		indptr, ind, data = csc.RawCSC()
+		if !isSame(golis.NewCSC(3, 4, indptr, ind, data), a) {
+			t.Fatalf("Value is not same for NewCSC")
+		}
+	})
+
+	t.Run("Symmetric", func(t *testing.T) {
+		a := mat.NewDense(3, 3, []float64{
+			8, 1, 6,
+			1, 0, 7,
+			6, 7, 2,
+		})
+		s := golis.NewSparseMatrixSymmetric(3)
+		for i := 0; i < 3; i++ {
+			for j := i; j < 3; j++ {
+				s.Add(i, j, a.At(i, j))
+			}
+		}
+		if !isSame(s.CSR(), a) || !isSame(s.CSC(), a) {
+			t.Fatalf("Value is not same")
+		}
+		if s.CSR().NNZ() != 8 {
+			t.Errorf("Not valid amount of elements: %d", s.CSR().NNZ())
+		}
+	})
+
+	t.Run("LsolveNative", func(t *testing.T) {
+		A := convectionDiffusion(20)
+		b := mat.NewDense(20, 1, nil)
+		for i := 0; i < 20; i++ {
+			b.Set(i, 0, 1.0)
+		}
+		for _, m := range []mat.Matrix{A.CSR(), A.CSC()} {
+			x, _, _, err := golis.LsolveNative(m, b, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r := residual(A, x, b); r > 1e-10 {
+				t.Errorf("Residual is too big: %v", r)
+			}
+		}
+	})
+}
+
+func TestCSRPanics(t *testing.T) {
+	s := golis.NewSparseMatrix(2, 2)
+	s.Add(0, 0, 1.0)
+	csr, csc := s.CSR(), s.CSC()
+	for name, f := range map[string]func(){
+		"At":          func() { _ = csr.At(2, 0) },
+		"Row":         func() { _, _ = csr.Row(-1) },
+		"CSCAt":       func() { _ = csc.At(0, 2) },
+		"Col":         func() { _, _ = csc.Col(2) },
+		"Size":        func() { golis.NewCSR(0, 2, []int{0}, nil, nil) },
+		"Pointers":    func() { golis.NewCSR(2, 2, []int{0, 1}, []int{0}, []float64{1}) },
+		"Amount":      func() { golis.NewCSR(2, 2, []int{0, 1, 1}, []int{0}, nil) },
+		"NotSorted":   func() { golis.NewCSR(1, 2, []int{0, 2}, []int{1, 0}, []float64{1, 1}) },
+		"Outside":     func() { golis.NewCSC(2, 1, []int{0, 1}, []int{2}, []float64{1}) },
+		"PointerDown": func() { golis.NewCSR(2, 2, []int{0, 1, 0}, []int{}, []float64{}) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				t.Logf("\n%v", r)
+				if r == nil {
+					t.Fatal("Haven`t panic for not valid data")
+				}
+			}()
+			f()
+		})
+	}
+}
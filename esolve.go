@@ -0,0 +1,214 @@
+package golis
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/Konstantin8105/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Esolve returns eigenpairs of standard or generalized eigenvalue problem
+// by `esolve` and `gesolve` executables of `lis` software.
+//
+//	A * x = λ * x     , if B is nil
+//	A * x = λ * B * x , if B is not nil
+//
+// Where: A, B are square matrixes with same sizes, λ is eigenvalue,
+// x is eigenvector.
+//
+// Results:
+//
+//	eigenvalues  - values λ, amount of values is size of subspace
+//	eigenvectors - matrix with eigenvectors in columns
+//	residuals    - residual norm of each eigenpair after last iteration
+//	iterations   - amount of iterations of each eigenpair
+//	output       - output of `lis` software
+//
+// Description of options, see in `lis` software documentation.
+// Some examples:
+//
+//	options = "-e pi"                , Use Power method for largest eigenvalue
+//	options = "-e ii -shift 1.0"     , Use Inverse method for eigenvalue nearest 1.0
+//	options = "-e si -ss 4"          , Use Subspace method for 4 eigenpairs
+//	options = "-e li -ss 4 -i cg"    , Use Lanczos method with inner solver CG
+func Esolve(A, B mat.Matrix, options string) (
+	eigenvalues []float64,
+	eigenvectors mat.Matrix,
+	residuals []float64,
+	iterations []int,
+	output string,
+	err error) {
+	return LisSolver{
+		Path:          LisPath,
+		TempDir:       LisTempDir,
+		KeepOnFailure: LisKeepOnFailure,
+	}.Esolve(A, B, options)
+}
+
+// EsolveContext is same as Esolve, but with context.
+// See description of LsolveContext.
+func EsolveContext(ctx context.Context, A, B mat.Matrix, options string) (
+	eigenvalues []float64,
+	eigenvectors mat.Matrix,
+	residuals []float64,
+	iterations []int,
+	output string,
+	err error) {
+	return LisSolver{
+		Path:          LisPath,
+		TempDir:       LisTempDir,
+		KeepOnFailure: LisKeepOnFailure,
+	}.EsolveContext(ctx, A, B, options)
+}
+
+// Esolve returns eigenpairs of eigenvalue problem by `esolve` and
+// `gesolve` executables of `lis` software. See description of Esolve.
+func (s LisSolver) Esolve(A, B mat.Matrix, options string) (
+	eigenvalues []float64,
+	eigenvectors mat.Matrix,
+	residuals []float64,
+	iterations []int,
+	output string,
+	err error) {
+	return s.EsolveContext(context.Background(), A, B, options)
+}
+
+// EsolveContext is same as Esolve, but with context.
+// See description of LsolveContext.
+func (s LisSolver) EsolveContext(ctx context.Context, A, B mat.Matrix, options string) (
+	eigenvalues []float64,
+	eigenvectors mat.Matrix,
+	residuals []float64,
+	iterations []int,
+	output string,
+	err error) {
+
+	// check size of input Matrixs
+	if err = checkEigenSystem(A, B); err != nil {
+		return
+	}
+
+	// create a temp folder
+	tmpDir, err := ioutil.TempDir(s.TempDir, "golis")
+	if err != nil {
+		return
+	}
+	defer s.cleanup(ctx, tmpDir, &err)
+
+	fn := func(name string) string {
+		return filepath.Join(tmpDir, name)
+	}
+
+	// temp files
+	var (
+		aFilename         = fn("a.mtx")
+		bFilename         = fn("b.mtx")
+		evaluesFilename   = fn("evalues.mtx")
+		evectorsFilename  = fn("evectors.mtx")
+		residualsFilename = fn("residuals.mtx")
+		itersFilename     = fn("iters.mtx")
+	)
+
+	if err = writeMatrixMarketFile(aFilename, A, nil, nil); err != nil {
+		return
+	}
+
+	// prepare arguments for `lis`
+	name := "esolve"
+	args := []string{aFilename}
+	if !isNil(B) {
+		if err = writeMatrixMarketFile(bFilename, B, nil, nil); err != nil {
+			return
+		}
+		name = "gesolve"
+		args = append(args, bFilename)
+	}
+	args = append(args,
+		evaluesFilename,
+		evectorsFilename,
+		residualsFilename,
+		itersFilename,
+	)
+
+	out, err := s.execute(ctx, name, args, options)
+	if err != nil {
+		return
+	}
+	output = string(out)
+
+	if eigenvalues, err = readVectorFile(evaluesFilename); err != nil {
+		return
+	}
+	if eigenvectors, err = readMatrixFile(evectorsFilename); err != nil {
+		return
+	}
+	if residuals, err = readVectorFile(residualsFilename); err != nil {
+		return
+	}
+	var iters []float64
+	if iters, err = readVectorFile(itersFilename); err != nil {
+		return
+	}
+	iterations = make([]int, len(iters))
+	for i := range iters {
+		iterations[i] = int(iters[i])
+	}
+
+	// check amount of eigenpairs
+	if _, c := eigenvectors.Dims(); c != len(eigenvalues) {
+		err = fmt.Errorf("Amount of eigenvectors is not same amount of eigenvalues: %d != %d",
+			c, len(eigenvalues))
+		return
+	}
+	if len(residuals) != len(eigenvalues) || len(iterations) != len(eigenvalues) {
+		err = fmt.Errorf("Amount of residuals or iterations is not same amount of eigenvalues: %d, %d != %d",
+			len(residuals), len(iterations), len(eigenvalues))
+		return
+	}
+	return
+}
+
+// readMatrixFile returns matrix from file in Matrix Market format
+func readMatrixFile(filename string) (mat.Matrix, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSparseMatrix(b)
+}
+
+// readVectorFile returns vector from file in Matrix Market format
+func readVectorFile(filename string) ([]float64, error) {
+	m, err := readMatrixFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, c := m.Dims(); c != 1 {
+		return nil, fmt.Errorf("File %s have not vector: %d columns", filename, c)
+	}
+	return vectorFromMatrix(m), nil
+}
+
+// checkEigenSystem returns error, if matrixes A and B is not valid
+// for eigenvalue problem A * x = λ * B * x
+func checkEigenSystem(A, B mat.Matrix) error {
+	var et errors.Tree
+	et.Name = "Check input matrixes A and B"
+	r, c := A.Dims()
+	if r != c {
+		et.Add(fmt.Errorf("Matrix A is not square: [%d,%d]", r, c))
+	}
+	if !isNil(B) {
+		if rb, cb := B.Dims(); rb != r || cb != c {
+			et.Add(fmt.Errorf("Sizes of matrix B is not same as matrix A: [%d,%d] != [%d,%d]",
+				rb, cb, r, c))
+		}
+	}
+	if et.IsError() {
+		return et
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package golis_test
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+// fakeEsolve returns folder with fake `esolve` and `gesolve` shell
+// scripts. Arguments after matrixes are written in file `args`.
+func fakeEsolve(t *testing.T, script string) string {
+	lisPath := fakeLis(t, "exit 1\n")
+	for _, tc := range []struct {
+		name  string
+		shift int // amount of matrix filenames
+	}{
+		{"esolve", 1},
+		{"gesolve", 2},
+	} {
+		s := "#!/bin/sh\nshift " + strconv.Itoa(tc.shift) + "\n" +
+			"echo " + tc.name + " > \"" + filepath.Join(lisPath, "name") + "\"\n" +
+			script
+		err := ioutil.WriteFile(filepath.Join(lisPath, tc.name), []byte(s), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return lisPath
+}
+
+// fakeEsolveSuccess is shell script with result of `esolve`
+// for matrix [[2 1] [1 2]] with subspace 2
+const fakeEsolveSuccess = `
+printf '%%%%MatrixMarket vector coordinate real general\n2\n1 1.0\n2 3.0\n' > "$1"
+printf '%%%%MatrixMarket matrix coordinate real general\n2 2 4\n1 1 0.7071067811865475\n2 1 -0.7071067811865475\n1 2 0.7071067811865475\n2 2 0.7071067811865475\n' > "$2"
+printf '%%%%MatrixMarket vector coordinate real general\n2\n1 1.0e-14\n2 2.0e-14\n' > "$3"
+printf '%%%%MatrixMarket vector coordinate real general\n2\n1 3\n2 4\n' > "$4"
+echo "eigensolver status    : normal end"
+`
+
+func TestEsolve(t *testing.T) {
+	lisPath := fakeEsolve(t, fakeEsolveSuccess)
+	defer func() { _ = os.RemoveAll(lisPath) }()
+
+	A := mat.NewDense(2, 2, []float64{
+		2.0, 1.0,
+		1.0, 2.0,
+	})
+	solver := golis.LisSolver{Path: lisPath}
+
+	for _, tc := range []struct {
+		name string
+		B    mat.Matrix
+	}{
+		{"esolve", nil},
+		{"esolve", (*mat.Dense)(nil)},
+		{"gesolve", mat.NewDiagDense(2, []float64{1, 1})},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values, vectors, residuals, iterations, output, err := solver.Esolve(A, tc.B, "-e si -ss 2")
+			if err != nil {
+				t.Fatal(err)
+			}
+			name, err := ioutil.ReadFile(filepath.Join(lisPath, "name"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(name) != tc.name+"\n" {
+				t.Errorf("Not valid executable: %s", name)
+			}
+			if len(values) != 2 || len(residuals) != 2 || len(output) == 0 {
+				t.Fatalf("Not valid results: %v %v\n%s", values, residuals, output)
+			}
+			if iterations[0] != 3 || iterations[1] != 4 {
+				t.Errorf("Not valid iterations: %v", iterations)
+			}
+			// check eigenpairs
+			for j, lambda := range values {
+				x := mat.Col(nil, j, vectors)
+				var ax mat.VecDense
+				ax.MulVec(A, mat.NewVecDense(2, x))
+				for i := range x {
+					if math.Abs(ax.AtVec(i)-lambda*x[i]) > 1e-12 {
+						t.Errorf("Not valid eigenpair %d: %v %v", j, lambda, x)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEsolveFail(t *testing.T) {
+	A := mat.NewDense(2, 2, []float64{
+		2.0, 1.0,
+		1.0, 2.0,
+	})
+
+	t.Run("Sizes", func(t *testing.T) {
+		for _, tc := range []struct {
+			A, B mat.Matrix
+		}{
+			{mat.NewDense(2, 3, nil), nil},
+			{A, mat.NewDense(3, 3, nil)},
+		} {
+			if _, _, _, _, _, err := golis.Esolve(tc.A, tc.B, ""); err == nil {
+				t.Errorf("Error is not found")
+			}
+		}
+	})
+
+	for _, tc := range []struct {
+		name   string
+		script string
+		err    error
+	}{
+		{"Maxiter", `echo "eigensolver status    : LIS_MAXITER(code=3)"`, golis.Maxiter},
+		{"Exit", "exit 1\n", nil},
+		{"Files", `echo "eigensolver status    : normal end"`, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lisPath := fakeEsolve(t, tc.script)
+			defer func() { _ = os.RemoveAll(lisPath) }()
+
+			solver := golis.LisSolver{Path: lisPath}
+			_, _, _, _, _, err := solver.Esolve(A, nil, "")
+			if err == nil {
+				t.Fatalf("Error is not found")
+			}
+			if tc.err != nil && err != tc.err {
+				t.Fatalf("Not correct error: %v", err)
+			}
+		})
+	}
+}
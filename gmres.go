@@ -0,0 +1,172 @@
+package golis
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// GMRES returns solution matrix of restarted GMRES(m) iterative solve for
+// linear system without external `lis` software.
+//
+//	A * x = b
+//
+// Where: A is matrix, b is right-hand vector, restart is size of
+// Krylov subspace before restart.
+//
+// Iterations are finished, if relative residual norm ||b-A*x||/||b||
+// is less or equal tol. Solution and residual history have same
+// format as result of function Lsolve.
+// If solution is not found after maxiter iterations, then error Maxiter
+// is returned. Error Breakdown is returned in case of method breakdown.
+func GMRES(A *SparseMatrix, b mat.Matrix, restart int, tol float64, maxiter int) (
+	solution mat.Matrix,
+	rhistory []float64,
+	err error) {
+
+	if err = checkSystem(A, b); err != nil {
+		return
+	}
+	if err = checkIterative(tol, maxiter); err != nil {
+		return
+	}
+	if restart <= 0 {
+		err = fmt.Errorf("Restart value is not valid: %d", restart)
+		return
+	}
+
+	x, rhistory, err := gmres(A, vectorFromMatrix(b), nil, nil, restart, tol, maxiter)
+	if err != nil {
+		return
+	}
+	n, _ := b.Dims()
+	solution = mat.NewDense(n, 1, x)
+	return
+}
+
+// gmres is right preconditioned restarted Generalized Minimal Residual
+// method with Givens rotations. If x0 is nil, then initial guess is zero.
+// If M is nil, then method is without preconditioner.
+func gmres(A matVec, b, x0 []float64, M Preconditioner, restart int, tol float64, maxiter int) (
+	x []float64,
+	rhistory []float64,
+	err error) {
+
+	n := len(b)
+	if restart > n {
+		restart = n
+	}
+	bnorm := floats.Norm(b, 2)
+	if bnorm == 0.0 {
+		// trivial solution
+		return make([]float64, n), []float64{0.0}, nil
+	}
+	r := make([]float64, n)
+	x = initial(A, b, x0, r)
+	resid := floats.Norm(r, 2) / bnorm
+	rhistory = append(rhistory, resid)
+	if resid <= tol {
+		return x, rhistory, nil
+	}
+
+	// Krylov basis, Hessenberg matrix and Givens rotations
+	var (
+		v  = make([][]float64, restart+1)
+		h  = make([][]float64, restart+1)
+		cs = make([]float64, restart)
+		sn = make([]float64, restart)
+		g  = make([]float64, restart+1)
+		y  = make([]float64, restart)
+		u  = make([]float64, n) // update of solution
+		z  = make([]float64, n) // preconditioned vector
+	)
+	for i := range v {
+		v[i] = make([]float64, n)
+		h[i] = make([]float64, restart)
+	}
+
+	iter := 0
+	for iter < maxiter {
+		beta := floats.Norm(r, 2)
+		for i := range v[0] {
+			v[0][i] = r[i] / beta
+		}
+		for i := range g {
+			g[i] = 0.0
+		}
+		g[0] = beta
+
+		var (
+			j         int
+			converged bool
+		)
+		for j = 0; j < restart && iter < maxiter; j++ {
+			iter++
+			// Arnoldi process with modified Gram-Schmidt
+			precondition(M, z, v[j])
+			A.mulVec(v[j+1], z)
+			for i := 0; i <= j; i++ {
+				h[i][j] = floats.Dot(v[j+1], v[i])
+				floats.AddScaled(v[j+1], -h[i][j], v[i])
+			}
+			h[j+1][j] = floats.Norm(v[j+1], 2)
+			if h[j+1][j] != 0.0 {
+				floats.Scale(1.0/h[j+1][j], v[j+1])
+			}
+
+			// apply previous rotations
+			for i := 0; i < j; i++ {
+				tmp := cs[i]*h[i][j] + sn[i]*h[i+1][j]
+				h[i+1][j] = -sn[i]*h[i][j] + cs[i]*h[i+1][j]
+				h[i][j] = tmp
+			}
+			// new rotation
+			denom := math.Hypot(h[j][j], h[j+1][j])
+			if denom == 0.0 {
+				return nil, rhistory, Breakdown
+			}
+			cs[j] = h[j][j] / denom
+			sn[j] = h[j+1][j] / denom
+			h[j][j] = denom
+			h[j+1][j] = 0.0
+			g[j+1] = -sn[j] * g[j]
+			g[j] = cs[j] * g[j]
+
+			resid = math.Abs(g[j+1]) / bnorm
+			rhistory = append(rhistory, resid)
+			if resid <= tol {
+				converged = true
+				j++
+				break
+			}
+		}
+
+		// solve upper triangular system H * y = g
+		for i := j - 1; i >= 0; i-- {
+			y[i] = g[i]
+			for k := i + 1; k < j; k++ {
+				y[i] -= h[i][k] * y[k]
+			}
+			y[i] /= h[i][i]
+		}
+		for i := range u {
+			u[i] = 0.0
+		}
+		for i := 0; i < j; i++ {
+			floats.AddScaled(u, y[i], v[i])
+		}
+		precondition(M, z, u)
+		floats.Add(x, z)
+		if converged {
+			return x, rhistory, nil
+		}
+
+		// true residual for restart
+		A.mulVec(r, x)
+		floats.SubTo(r, b, r)
+	}
+
+	return nil, rhistory, Maxiter
+}
@@ -2,8 +2,10 @@ package golis
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
@@ -18,6 +20,15 @@ import (
 //	golis.LisPath = "/home/user/lis/bin/"
 var LisPath string
 
+// LisTempDir is base folder for temp files of `lis` software.
+// If LisTempDir is empty, then default folder for temporary files is used.
+var LisTempDir string
+
+// LisKeepOnFailure is flag for keeping temp files of `lis` software
+// in case of error. Temp folder is reported in error.
+// Temp files are always removed after successful solving.
+var LisKeepOnFailure bool
+
 // ErrorValue is error retirn value as result of `lis` software working
 type ErrorValue int
 
@@ -58,48 +69,130 @@ var errorStrings = []string{
 //	options    = "-i gmres -restart 20"       , Use solver GMRES with restart 20
 //	options    = "-i bicgstab -maxiter 20000" , Use solver BiCGSTAB with max iteration 20000
 //
+// If b have several columns, then each column is solved separately with
+// same matrix A, see LsolveMultiple. Solution have same sizes as b,
+// residual histories and outputs of columns are joined in order of
+// columns. For separate residual histories, use LsolveMultiple.
 func Lsolve(A, b mat.Matrix, options string) (
 	solution mat.Matrix,
 	rhistory []float64,
 	output string,
 	err error) {
+	return LisSolver{
+		Path:          LisPath,
+		TempDir:       LisTempDir,
+		KeepOnFailure: LisKeepOnFailure,
+	}.Solve(A, b, options)
+}
 
-	// check size of input Matrixs
-	var et errors.Tree
-	et.Name = "Check input matrix A and vector b"
-	if r, c := A.Dims(); r != c {
-		et.Add(fmt.Errorf("Matrix A is not square: [%d,%d]", r, c))
-	}
-	if r, c := b.Dims(); !(r > 0 && c == 1) {
-		et.Add(fmt.Errorf("Vector b is not vertical vector: [%d,%d]", r, c))
+// LsolveContext is same as Lsolve, but `lsolve` process is killed
+// if the context is done before the process finished. In that case
+// temp folder is removed and context error is returned:
+// context.Canceled or context.DeadlineExceeded.
+func LsolveContext(ctx context.Context, A, b mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return LisSolver{
+		Path:          LisPath,
+		TempDir:       LisTempDir,
+		KeepOnFailure: LisKeepOnFailure,
+	}.SolveContext(ctx, A, b, options)
+}
+
+// Solve returns solution matrix of iterative solve for linear system
+// by `lsolve` executable of `lis` software. See description of Lsolve.
+func (s LisSolver) Solve(A, b mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return s.SolveContext(context.Background(), A, b, options)
+}
+
+// SolveContext is same as Solve, but with context.
+// See description of LsolveContext.
+func (s LisSolver) SolveContext(ctx context.Context, A, b mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return s.SolveInitialContext(ctx, A, b, nil, options)
+}
+
+// LsolveInitial is same as Lsolve, but with initial guess x0 of
+// solution. For example, solution of previous time step.
+// Vector x0 is written in input file after vector b and option
+// "-initx_zeros false" is added before options, so x0 is ignored with
+// option "-initx_zeros true". If x0 is nil, then initial guess is zero.
+// Residual history is started from residual of initial guess x0, but
+// not of zero vector.
+func LsolveInitial(A, b, x0 mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return LisSolver{
+		Path:          LisPath,
+		TempDir:       LisTempDir,
+		KeepOnFailure: LisKeepOnFailure,
+	}.SolveInitial(A, b, x0, options)
+}
+
+// SolveInitial returns solution matrix of iterative solve for linear
+// system with initial guess x0. See description of LsolveInitial.
+func (s LisSolver) SolveInitial(A, b, x0 mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return s.SolveInitialContext(context.Background(), A, b, x0, options)
+}
+
+// SolveInitialContext is same as SolveInitial, but with context.
+// See description of LsolveContext.
+func (s LisSolver) SolveInitialContext(ctx context.Context, A, b, x0 mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+
+	if isNil(x0) {
+		x0 = nil
 	}
-	{
-		r, _ := A.Dims()
-		if rb, _ := b.Dims(); r != rb {
-			et.Add(fmt.Errorf("Amount of matrix and vector b is not same"))
-		}
+
+	// several right-hand vectors
+	if _, c := b.Dims(); c > 1 && x0 == nil {
+		var (
+			rhs  [][]float64
+			outs []string
+		)
+		solution, rhs, outs, err = s.SolveMultipleContext(ctx, A, b, options)
+		rhistory, output = joinResults(rhs, outs)
+		return
 	}
-	if et.IsError() {
-		err = et
+
+	// check size of input Matrixs
+	if err = checkSystem(A, b); err != nil {
 		return
 	}
+	if x0 != nil {
+		if err = checkInitial(A, x0); err != nil {
+			return
+		}
+		options = "-initx_zeros false " + options
+	}
 
 	// create a temp folder
-	tmpDir, err := ioutil.TempDir("", "")
+	tmpDir, err := ioutil.TempDir(s.TempDir, "golis")
 	if err != nil {
 		return
 	}
-	defer func() {
-		if err != nil {
-			var et errors.Tree
-			et.Add(err)
-			et.Add(fmt.Errorf("Temp folder: %v", tmpDir))
-			err = et
-		}
-	}()
+	defer s.cleanup(ctx, tmpDir, &err)
 
 	fn := func(name string) string {
-		return filepath.Join(tmpDir, string(filepath.Separator), name)
+		return filepath.Join(tmpDir, name)
 	}
 
 	// temp files
@@ -109,62 +202,346 @@ func Lsolve(A, b mat.Matrix, options string) (
 		rhistoryFilename = fn("rhistory.txt")
 	)
 
-	inp := convertMatrixWithVector(A, b)
-	err = ioutil.WriteFile(inputFilename, inp, 0644)
+	err = writeMatrixMarketFile(inputFilename, A, b, x0)
 	if err != nil {
 		return
 	}
 
+	return s.lsolve(ctx, options,
+		inputFilename,
+		"0", // matrix b in inputFilename
+		solutionFilename,
+		rhistoryFilename)
+}
+
+// LsolveMultiple returns solution matrix of iterative solve for linear
+// systems with same matrix A and several right-hand vectors.
+//
+//	A * X = B
+//
+// Where: A is matrix, B is matrix with right-hand vectors in columns.
+// Matrix A is written in temp file only once and each column of B is
+// solved separately. Solution have same sizes as B. Residual history
+// and output of `lsolve` are returned for each column.
+// In case of error, rhistory and output have results only for solved
+// columns, so index of failed column is len(rhistory).
+// Description of options, see in Lsolve.
+func LsolveMultiple(A, B mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory [][]float64,
+	output []string,
+	err error) {
+	return LisSolver{
+		Path:          LisPath,
+		TempDir:       LisTempDir,
+		KeepOnFailure: LisKeepOnFailure,
+	}.SolveMultiple(A, B, options)
+}
+
+// LsolveMultipleContext is same as LsolveMultiple, but with context.
+// See description of LsolveContext.
+func LsolveMultipleContext(ctx context.Context, A, B mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory [][]float64,
+	output []string,
+	err error) {
+	return LisSolver{
+		Path:          LisPath,
+		TempDir:       LisTempDir,
+		KeepOnFailure: LisKeepOnFailure,
+	}.SolveMultipleContext(ctx, A, B, options)
+}
+
+// SolveMultiple returns solution matrix of iterative solve for linear
+// systems with several right-hand vectors by `lsolve` executable of
+// `lis` software. See description of LsolveMultiple.
+func (s LisSolver) SolveMultiple(A, B mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory [][]float64,
+	output []string,
+	err error) {
+	return s.SolveMultipleContext(context.Background(), A, B, options)
+}
+
+// SolveMultipleContext is same as SolveMultiple, but with context.
+// See description of LsolveContext.
+func (s LisSolver) SolveMultipleContext(ctx context.Context, A, B mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory [][]float64,
+	output []string,
+	err error) {
+
+	// check size of input Matrixs
+	if err = checkMultipleSystem(A, B); err != nil {
+		return
+	}
+
+	// create a temp folder
+	tmpDir, err := ioutil.TempDir(s.TempDir, "golis")
+	if err != nil {
+		return
+	}
+	defer s.cleanup(ctx, tmpDir, &err)
+
+	fn := func(name string) string {
+		return filepath.Join(tmpDir, name)
+	}
+
+	// matrix A is written only once
+	inputFilename := fn("input.mtx")
+	err = writeMatrixMarketFile(inputFilename, A, nil, nil)
+	if err != nil {
+		return
+	}
+
+	r, c := B.Dims()
+	sol := mat.NewDense(r, c, nil)
+	for j := 0; j < c; j++ {
+		var (
+			rhsFilename      = fn(fmt.Sprintf("rhs%d.mtx", j))
+			solutionFilename = fn(fmt.Sprintf("solution%d.mtx", j))
+			rhistoryFilename = fn(fmt.Sprintf("rhistory%d.txt", j))
+		)
+		err = writeVectorFile(rhsFilename, B, j)
+		if err != nil {
+			return
+		}
+		var (
+			x  mat.Matrix
+			rh []float64
+			o  string
+		)
+		x, rh, o, err = s.lsolve(ctx, options,
+			inputFilename,
+			rhsFilename,
+			solutionFilename,
+			rhistoryFilename)
+		if err != nil {
+			return
+		}
+		for i := 0; i < r; i++ {
+			sol.Set(i, j, x.At(i, 0))
+		}
+		rhistory = append(rhistory, rh)
+		output = append(output, o)
+	}
+	solution = sol
+	return
+}
+
+// joinResults returns residual histories and outputs of several
+// right-hand vectors joined in order of columns
+func joinResults(rhs [][]float64, outs []string) (rhistory []float64, output string) {
+	for j := range rhs {
+		rhistory = append(rhistory, rhs[j]...)
+	}
+	return rhistory, strings.Join(outs, "\n")
+}
+
+// cleanup removes temp folder after solving. Temp folder is not removed
+// in case of error with flag KeepOnFailure, except of context error.
+func (s LisSolver) cleanup(ctx context.Context, tmpDir string, err *error) {
+	if ctxErr := ctx.Err(); *err != nil && ctxErr != nil {
+		// solving is cancelled
+		_ = os.RemoveAll(tmpDir)
+		*err = ctxErr
+		return
+	}
+	if *err != nil && s.KeepOnFailure {
+		var et errors.Tree
+		et.Add(*err)
+		et.Add(fmt.Errorf("Temp folder: %v", tmpDir))
+		*err = et
+		return
+	}
+	_ = os.RemoveAll(tmpDir)
+}
+
+// lsolve runs `lsolve` executable and returns parsed results.
+// Argument rhs is rhs setting of `lsolve`: "0" for vector in
+// input file or filename of vector.
+func (s LisSolver) lsolve(ctx context.Context, options string,
+	inputFilename, rhs, solutionFilename, rhistoryFilename string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+
 	// prepare arguments for `lis`
 	args := []string{
 		inputFilename,
-		"0", // matrix b in inputFilename
+		rhs,
 		solutionFilename,
 		rhistoryFilename,
 	}
-	args = append(args, strings.Split(options, " ")...)
 
-	cmd := exec.Command(filepath.Join(LisPath, "lsolve"), args...)
+	out, err := s.execute(ctx, "lsolve", args, options)
+	if err != nil {
+		return
+	}
+	output = string(out)
+
+	sol, err := ioutil.ReadFile(solutionFilename)
+	if err != nil {
+		return
+	}
+	solution, err = ParseSparseMatrix(sol)
+	if err != nil {
+		return
+	}
+
+	// parsing rhistory
+	rhistory, err = parseRHistory(rhistoryFilename)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// execute runs executable of `lis` software with arguments and options
+// and returns output. If output have error of `lis` software, then
+// ErrorValue is returned.
+func (s LisSolver) execute(ctx context.Context, name string, args []string,
+	options string) (out []byte, err error) {
+
+	args = append(args, strings.Fields(options)...)
+
+	cmd := exec.CommandContext(ctx, filepath.Join(s.Path, name), args...)
 	var outBuf bytes.Buffer
 	cmd.Stdout = &outBuf
 	var errBuf bytes.Buffer
 	cmd.Stderr = &errBuf
 	err = cmd.Run()
 	if err != nil {
-		err = fmt.Errorf("Error result of execute `lsolve`: %v\n%v\n%v",
-			err, outBuf.String(), errBuf.String())
+		err = fmt.Errorf("Error result of execute `%s`: %v\n%v\n%v",
+			name, err, outBuf.String(), errBuf.String())
 		return
 	}
-	out := outBuf.Bytes()
+	out = outBuf.Bytes()
 
 	// Example of result parsing:
 	// linear solver status  : normal end
 	// linear solver status  : LIS_BREAKDOWN(code=2)
 	for i, e := range errorStrings {
 		if bytes.Contains(out, []byte(e)) {
-			err = ErrorValue(i)
-			return
+			return nil, ErrorValue(i)
 		}
 	}
+	return
+}
 
-	output = string(out)
-
-	sol, err := ioutil.ReadFile(solutionFilename)
+// writeMatrixMarketFile writes matrix A with vector b and initial
+// guess x0 in file. If b is nil, then only matrix A is written.
+// If x0 is nil, then initial guess is not written.
+func writeMatrixMarketFile(filename string, A, b, x0 mat.Matrix) (err error) {
+	f, err := os.Create(filename)
 	if err != nil {
 		return
 	}
-	solution, err = ParseSparseMatrix(sol)
-	if err != nil {
-		return
+	defer func() {
+		if errClose := f.Close(); err == nil {
+			err = errClose
+		}
+	}()
+	switch {
+	case b == nil:
+		return WriteMatrixMarket(f, A)
+	case x0 == nil:
+		return WriteMatrixMarketWithVector(f, A, b)
 	}
+	return WriteMatrixMarketWithVectors(f, A, b, x0)
+}
 
-	// parsing rhistory
-	rhistory, err = parseRHistory(rhistoryFilename)
+// writeVectorFile writes column j of matrix B in file as vector
+// in Matrix Market format.
+//
+// Example:
+//
+//	%%MatrixMarket vector coordinate real general
+//	2
+//	1 5.0000000000000000e+00
+//	2 6.0000000000000000e+00
+func writeVectorFile(filename string, B mat.Matrix, j int) (err error) {
+	f, err := os.Create(filename)
 	if err != nil {
 		return
 	}
+	defer func() {
+		if errClose := f.Close(); err == nil {
+			err = errClose
+		}
+	}()
+	r, _ := B.Dims()
+	e := NewMatrixMarketEncoder(f)
+	if err = e.WriteHeader(mmVector, mmGeneral, r); err != nil {
+		return
+	}
+	for i := 0; i < r; i++ {
+		if err = e.WriteVectorEntry(i, B.At(i, j)); err != nil {
+			return
+		}
+	}
+	return e.Flush()
+}
 
-	return
+// checkSystem returns error, if matrix A and vector b is not valid
+// for linear system A * x = b
+func checkSystem(A, b mat.Matrix) error {
+	var et errors.Tree
+	et.Name = "Check input matrix A and vector b"
+	if r, c := A.Dims(); r != c {
+		et.Add(fmt.Errorf("Matrix A is not square: [%d,%d]", r, c))
+	}
+	if r, c := b.Dims(); !(r > 0 && c == 1) {
+		et.Add(fmt.Errorf("Vector b is not vertical vector: [%d,%d]", r, c))
+	}
+	{
+		r, _ := A.Dims()
+		if rb, _ := b.Dims(); r != rb {
+			et.Add(fmt.Errorf("Amount of matrix and vector b is not same"))
+		}
+	}
+	if et.IsError() {
+		return et
+	}
+	return nil
+}
+
+// checkInitial returns error, if initial guess x0 is not valid
+// for matrix A
+func checkInitial(A, x0 mat.Matrix) error {
+	r, _ := A.Dims()
+	if rx, cx := x0.Dims(); rx != r || cx != 1 {
+		var et errors.Tree
+		et.Name = "Check initial guess x0"
+		et.Add(fmt.Errorf("Vector x0 is not valid: [%d,%d] != [%d,1]", rx, cx, r))
+		return et
+	}
+	return nil
+}
+
+// checkMultipleSystem returns error, if matrix A and matrix B is not
+// valid for linear systems A * X = B
+func checkMultipleSystem(A, B mat.Matrix) error {
+	var et errors.Tree
+	et.Name = "Check input matrix A and matrix B"
+	if r, c := A.Dims(); r != c {
+		et.Add(fmt.Errorf("Matrix A is not square: [%d,%d]", r, c))
+	}
+	if r, c := B.Dims(); !(r > 0 && c > 0) {
+		et.Add(fmt.Errorf("Matrix B is empty: [%d,%d]", r, c))
+	}
+	{
+		r, _ := A.Dims()
+		if rb, _ := B.Dims(); r != rb {
+			et.Add(fmt.Errorf("Amount of rows of matrix A and matrix B is not same"))
+		}
+	}
+	if et.IsError() {
+		return et
+	}
+	return nil
 }
 
 // parseRHistory parsing rhs history
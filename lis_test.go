@@ -1,11 +1,16 @@
 package golis_test
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Konstantin8105/golis"
 	"gonum.org/v1/gonum/mat"
@@ -257,3 +262,243 @@ func BenchmarkLsolve(b *testing.B) {
 		}
 	}
 }
+
+// fakeLis returns folder with fake `lsolve` shell script
+func fakeLis(t *testing.T, script string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("test use shell script")
+	}
+	lisPath, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(lisPath, "lsolve"),
+		[]byte("#!/bin/sh\n"+script), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lisPath
+}
+
+// fakeLsolveSuccess is shell script with result of `lsolve`
+// for system [[1 2] [4 1]] * x = [4 9]
+const fakeLsolveSuccess = `
+printf '%%%%MatrixMarket vector coordinate real general\n2\n1 2.0\n2 1.0\n' > "$3"
+printf '1.000000e+00\n0.000000e+00\n' > "$4"
+echo "linear solver status  : normal end"
+`
+
+func TestLsolveContext(t *testing.T) {
+	// fake `lsolve` with infinite working
+	lisPath := fakeLis(t, "exec sleep 60\n")
+	defer func() { _ = os.RemoveAll(lisPath) }()
+
+	// scratch folder for temp files
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	A := mat.NewDense(2, 2, []float64{
+		1.0, 2.0,
+		4.0, 1.0,
+	})
+	b := mat.NewDense(2, 1, []float64{
+		4.0,
+		9.0,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	solver := golis.LisSolver{Path: lisPath, TempDir: tmpDir, KeepOnFailure: true}
+	_, _, _, err = solver.SolveContext(ctx, A, b, "")
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Not correct error: %v", err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("Process is not killed: %v", d)
+	}
+	if files, _ := ioutil.ReadDir(tmpDir); len(files) != 0 {
+		t.Errorf("Temp folder is not removed: %v", files)
+	}
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _, _, err := golis.LsolveContext(ctx, A, b, "")
+		if err != context.Canceled {
+			t.Fatalf("Not correct error: %v", err)
+		}
+	})
+}
+
+func TestLsolveTempDir(t *testing.T) {
+	A := mat.NewDense(2, 2, []float64{
+		1.0, 2.0,
+		4.0, 1.0,
+	})
+	b := mat.NewDense(2, 1, []float64{
+		4.0,
+		9.0,
+	})
+
+	for _, tc := range []struct {
+		name          string
+		script        string
+		keepOnFailure bool
+		isErr         bool
+		amountFiles   int
+	}{
+		{"Success", fakeLsolveSuccess, false, false, 0},
+		{"SuccessKeep", fakeLsolveSuccess, true, false, 0},
+		{"Failure", "exit 1\n", false, true, 0},
+		{"FailureKeep", "exit 1\n", true, true, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lisPath := fakeLis(t, tc.script)
+			defer func() { _ = os.RemoveAll(lisPath) }()
+
+			tmpDir, err := ioutil.TempDir("", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = os.RemoveAll(tmpDir) }()
+
+			solver := golis.LisSolver{
+				Path:          lisPath,
+				TempDir:       tmpDir,
+				KeepOnFailure: tc.keepOnFailure,
+			}
+			s, _, _, err := solver.Solve(A, b, "")
+			if (err != nil) != tc.isErr {
+				t.Fatalf("Not correct error: %v", err)
+			}
+			if err == nil && math.Abs(s.At(0, 0)-2) >= 1e-10 {
+				t.Errorf("Element 0,0 is not correct : %v", s.At(0, 0))
+			}
+			files, _ := ioutil.ReadDir(tmpDir)
+			if len(files) != tc.amountFiles {
+				t.Errorf("Not correct amount of temp folders: %d", len(files))
+			}
+		})
+	}
+}
+
+func TestLsolveMultiple(t *testing.T) {
+	// fake `lsolve` for identity matrix: solution is right-hand vector.
+	// Matrix file must be without vector and is same for all columns.
+	lisPath := fakeLis(t, `
+head -2 "$1" | tail -1 | grep -q "^2 2 2$" || exit 1
+[ "$2" != "0" ] || exit 1
+if grep -q "1.3000000000000000e+01" "$2"; then
+	echo "linear solver status  : LIS_BREAKDOWN(code=2)"
+	exit 0
+fi
+cp "$2" "$3"
+printf '1.000000e+00\n0.000000e+00\n' > "$4"
+echo "linear solver status  : normal end"
+`)
+	defer func() { _ = os.RemoveAll(lisPath) }()
+
+	A := golis.NewSparseMatrix(2, 2)
+	A.Set(0, 0, 1.0)
+	A.Set(1, 1, 1.0)
+	B := mat.NewDense(2, 3, []float64{
+		1.0, 3.0, 5.0,
+		2.0, 4.0, 6.0,
+	})
+
+	solver := golis.LisSolver{Path: lisPath}
+	s, rhistory, output, err := solver.SolveMultiple(A, B, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mat.Equal(s, B) {
+		t.Fatalf("Not correct solution:\n%v", mat.Formatted(s))
+	}
+	if len(rhistory) != 3 || len(output) != 3 {
+		t.Fatalf("Not correct amount of results: %d, %d", len(rhistory), len(output))
+	}
+	for j := range rhistory {
+		if len(rhistory[j]) != 2 {
+			t.Errorf("Not correct rhistory of column %d: %v", j, rhistory[j])
+		}
+	}
+
+	// several columns by Solve
+	{
+		s, rhistory, output, err := solver.Solve(A, B, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !mat.Equal(s, B) {
+			t.Fatalf("Not correct solution:\n%v", mat.Formatted(s))
+		}
+		if len(rhistory) != 6 || strings.Count(output, "normal end") != 3 {
+			t.Fatalf("Not correct joined results: %v\n%s", rhistory, output)
+		}
+	}
+
+	// error in second column
+	B.Set(0, 1, 13.0)
+	_, rhistory, output, err = solver.SolveMultiple(A, B, "")
+	if err != golis.Breakdown {
+		t.Fatalf("Not correct error: %v", err)
+	}
+	if len(rhistory) != 1 || len(output) != 1 {
+		t.Fatalf("Not correct amount of results: %d, %d", len(rhistory), len(output))
+	}
+
+	// not valid sizes
+	_, _, _, err = solver.SolveMultiple(A, mat.NewDense(3, 2, nil), "")
+	if err == nil {
+		t.Fatalf("Haven`t error for not valid sizes")
+	}
+	t.Log(err)
+}
+
+func TestLsolveInitial(t *testing.T) {
+	// fake `lsolve` checks initial guess in input file and option
+	lisPath := fakeLis(t, `
+head -2 "$1" | tail -1 | grep -q "^2 2 4 1 1$" || exit 1
+tail -2 "$1" | head -1 | grep -q "^1 2.0000000000000000e+00$" || exit 1
+echo "$@" | grep -q -- "-initx_zeros false" || exit 1
+`+fakeLsolveSuccess)
+	defer func() { _ = os.RemoveAll(lisPath) }()
+
+	A := mat.NewDense(2, 2, []float64{
+		1.0, 2.0,
+		4.0, 1.0,
+	})
+	b := mat.NewDense(2, 1, []float64{
+		4.0,
+		9.0,
+	})
+	x0 := mat.NewDense(2, 1, []float64{
+		2.0,
+		0.5,
+	})
+
+	solver := golis.LisSolver{Path: lisPath}
+	s, _, _, err := solver.SolveInitial(A, b, x0, "-i cg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(s.At(0, 0)-2) >= 1e-10 {
+		t.Errorf("Element 0,0 is not correct : %v", s.At(0, 0))
+	}
+
+	// without initial guess fake `lsolve` is failed
+	if _, _, _, err = solver.SolveInitial(A, b, nil, ""); err == nil {
+		t.Fatalf("Haven`t error")
+	}
+
+	// not valid initial guess
+	if _, _, _, err = solver.SolveInitial(A, b, mat.NewDense(2, 2, nil), ""); err == nil {
+		t.Fatalf("Haven`t error")
+	}
+	t.Log(err)
+}
@@ -3,40 +3,125 @@ package golis
 import (
 	"bytes"
 	"fmt"
-	"strconv"
+	"io"
+	"reflect"
+	"strings"
 
+	"github.com/Konstantin8105/errors"
 	"gonum.org/v1/gonum/mat"
 )
 
-// convertMatrixWithVector - convert matrix and vector to byte slice in
-// Matrix Market format
+// WriteMatrixMarket writes matrix in Matrix Market format.
 // See description:
 // https://math.nist.gov/MatrixMarket/formats.html
 //
 // Coordinate Format for Sparse Matrices
 // Format of MM        : coordinate
-// Type of output data : matrix with vector
+// Type of output data : matrix
 // Type of values      : real
-// Type of matrix      : general
-func convertMatrixWithVector(A, b mat.Matrix) []byte {
-	// TODO : add to specific package mmatrix
-	var buf bytes.Buffer
+// Type of matrix      : general or symmetric
+//
+// Symmetric matrix is written with header `symmetric` and only
+// half of elements. Stored upper triangle of SparseMatrixSymmetric and
+// symmetric PatternMatrix is written as lower triangle in according to
+// Matrix Market format.
+func WriteMatrixMarket(w io.Writer, A mat.Matrix) error {
+	return writeMatrixMarket(w, A, nil, nil)
+}
 
-	buf.WriteString("%%MatrixMarket matrix coordinate real general\n")
+// WriteMatrixMarketWithVector writes matrix with right-hand vector b
+// in Matrix Market format of `lis` software. Sizes line of matrix have
+// additional values "1 0" and after matrix elements all values of
+// vector b are written.
+// See description of WriteMatrixMarket.
+//
+// Example:
+//
+//	%%MatrixMarket matrix coordinate real general
+//	2 2 2 1 0
+//	1 1 1.0000000000000000e+00
+//	2 2 2.0000000000000000e+00
+//	1 5.0000000000000000e+00
+//	2 6.0000000000000000e+00
+func WriteMatrixMarketWithVector(w io.Writer, A, b mat.Matrix) error {
+	rA, _ := A.Dims()
+	if rb, cb := b.Dims(); rA != rb || cb != 1 {
+		return fmt.Errorf("Input `b` is not valid vector: [%d,%d]", rb, cb)
+	}
+	return writeMatrixMarket(w, A, b, nil)
+}
 
-	rA, cA := A.Dims()
-	rb, cb := b.Dims()
+// WriteMatrixMarketWithVectors writes matrix with right-hand vector b
+// and initial guess x0 in Matrix Market format of `lis` software.
+// Sizes line of matrix have additional values "1 1" and after matrix
+// elements all values of vector b and after all values of vector x0
+// are written. See description of WriteMatrixMarketWithVector.
+//
+// Example:
+//
+//	%%MatrixMarket matrix coordinate real general
+//	2 2 2 1 1
+//	1 1 1.0000000000000000e+00
+//	2 2 2.0000000000000000e+00
+//	1 5.0000000000000000e+00
+//	2 6.0000000000000000e+00
+//	1 4.0000000000000000e+00
+//	2 3.0000000000000000e+00
+func WriteMatrixMarketWithVectors(w io.Writer, A, b, x0 mat.Matrix) error {
+	if isNil(b) || isNil(x0) {
+		return fmt.Errorf("Input `b` or `x0` is nil")
+	}
+	rA, _ := A.Dims()
+	if rb, cb := b.Dims(); rA != rb || cb != 1 {
+		return fmt.Errorf("Input `b` is not valid vector: [%d,%d]", rb, cb)
+	}
+	if rx, cx := x0.Dims(); rA != rx || cx != 1 {
+		return fmt.Errorf("Input `x0` is not valid vector: [%d,%d]", rx, cx)
+	}
+	return writeMatrixMarket(w, A, b, x0)
+}
+
+// writeMatrixMarket writes matrix A and vector b, if b is not nil,
+// and vector x0, if x0 is not nil. Vector x0 is written only with b.
+func writeMatrixMarket(w io.Writer, A, b, x0 mat.Matrix) error {
+	if b == nil && x0 != nil {
+		return fmt.Errorf("Initial guess x0 is written without vector b")
+	}
+	e := NewMatrixMarketEncoder(w)
 
-	if cb != 1 {
-		panic(fmt.Errorf("Input `b` is not vector: [%d,%d]", rb, cb))
+	symmetry := mmGeneral
+	if _, ok := A.(mat.Symmetric); ok {
+		symmetry = mmSymmetric
+	}
+	if p, ok := A.(*PatternMatrix); ok && p.symmetric {
+		symmetry = mmSymmetric
 	}
 
+	rA, cA := A.Dims()
+
 	// amount of non-zero values
 	var nonZeros int
-	// TODO add optimization for SparseMatrix
 	switch v := A.(type) {
 	case *SparseMatrix:
+		v.compress()
 		nonZeros = len(v.data.ts)
+	case *SparseMatrixSymmetric:
+		v.s.compress()
+		nonZeros = len(v.s.data.ts)
+	case *PatternMatrix:
+		for _, d := range v.cs.data {
+			if d != 0.0 {
+				nonZeros++
+			}
+		}
+	case mat.Symmetric:
+		for i := 0; i < rA; i++ {
+			for j := 0; j <= i; j++ {
+				if A.At(i, j) != 0.0 {
+					nonZeros++
+				}
+			}
+		}
 	default:
 		for i := 0; i < rA; i++ {
 			for j := 0; j < cA; j++ {
@@ -46,38 +131,123 @@ func convertMatrixWithVector(A, b mat.Matrix) []byte {
 			}
 		}
 	}
+
 	// write sizes
-	// add string "1 0" for indicate that is matrix with vector
-	buf.WriteString(fmt.Sprintf("%d %d %d 1 0\n", rA, cA, nonZeros))
+	var err error
+	switch {
+	case b == nil:
+		err = e.WriteHeader(mmMatrix, symmetry, rA, cA, nonZeros)
+	case x0 == nil:
+		// add string "1 0" for indicate that is matrix with vector
+		err = e.WriteHeader(mmMatrix, symmetry, rA, cA, nonZeros, 1, 0)
+	default:
+		// add string "1 1" for indicate that is matrix with vector
+		// and initial guess
+		err = e.WriteHeader(mmMatrix, symmetry, rA, cA, nonZeros, 1, 1)
+	}
+	if err != nil {
+		return err
+	}
 
 	// write matrix A
 	switch v := A.(type) {
 	case *SparseMatrix:
-		v.compress()
 		for i := range v.data.ts {
 			r := int(v.data.ts[i].position % int64(v.r))
 			c := int(v.data.ts[i].position / int64(v.r))
-			buf.WriteString(fmt.Sprintf("%d %d %20.16e\n", r+1, c+1, v.data.ts[i].d))
+			if err := e.WriteEntry(r, c, v.data.ts[i].d); err != nil {
+				return err
+			}
+		}
+	case *SparseMatrixSymmetric:
+		for i := range v.s.data.ts {
+			r := int(v.s.data.ts[i].position % int64(v.s.r))
+			c := int(v.s.data.ts[i].position / int64(v.s.r))
+			// upper triangle element [r,c] is written as lower [c,r]
+			if err := e.WriteEntry(c, r, v.s.data.ts[i].d); err != nil {
+				return err
+			}
+		}
+	case *PatternMatrix:
+		for c := 0; c < v.c; c++ {
+			ind, data := v.cs.vector(c)
+			for k, r := range ind {
+				if data[k] == 0.0 {
+					continue
+				}
+				var err error
+				if v.symmetric {
+					// upper triangle element [r,c] is written as lower [c,r]
+					err = e.WriteEntry(c, r, data[k])
+				} else {
+					err = e.WriteEntry(r, c, data[k])
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case mat.Symmetric:
+		for i := 0; i < rA; i++ {
+			for j := 0; j <= i; j++ {
+				if A.At(i, j) != 0.0 {
+					if err := e.WriteEntry(i, j, A.At(i, j)); err != nil {
+						return err
+					}
+				}
+			}
 		}
 	default:
 		for i := 0; i < rA; i++ {
 			for j := 0; j < cA; j++ {
 				if A.At(i, j) != 0.0 {
-					buf.WriteString(fmt.Sprintf("%d %d %20.16e\n", i+1, j+1, A.At(i, j)))
+					if err := e.WriteEntry(i, j, A.At(i, j)); err != nil {
+						return err
+					}
 				}
 			}
 		}
 	}
-	// write vector b must be Dense
-	for i := 0; i < rb; i++ {
-		buf.WriteString(fmt.Sprintf("%d %20.16e\n", i+1, b.At(i, 0)))
+
+	// write vector b and initial guess x0
+	if b != nil {
+		if err := writeVectorEntries(e, b); err != nil {
+			return err
+		}
+	}
+	if x0 != nil {
+		if err := writeVectorEntries(e, x0); err != nil {
+			return err
+		}
 	}
 
-	return buf.Bytes()
+	return e.Flush()
+}
+
+// writeVectorEntries writes all values of first column of matrix v
+func writeVectorEntries(e *MatrixMarketEncoder, v mat.Matrix) error {
+	rv, _ := v.Dims()
+	for i := 0; i < rv; i++ {
+		if err := e.WriteVectorEntry(i, v.At(i, 0)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isNil returns true, if matrix is nil or typed nil pointer,
+// for example (*mat.Dense)(nil)
+func isNil(m mat.Matrix) bool {
+	if m == nil {
+		return true
+	}
+	v := reflect.ValueOf(m)
+	return v.Kind() == reflect.Ptr && v.IsNil()
 }
 
 // ParseSparseMatrix returns sparse matrix parsed from byte slice in
-// MatrixMarket format and error, if exist
+// MatrixMarket format and error, if exist.
+// See description of ReadMatrixMarket.
 //
 // Example:
 //
@@ -88,53 +258,173 @@ func convertMatrixWithVector(A, b mat.Matrix) []byte {
 //  3   4.99999999999999911182e+00
 //
 func ParseSparseMatrix(b []byte) (mat.Matrix, error) {
-	lines := bytes.Split(b, []byte("\n"))
+	return ReadMatrixMarket(bytes.NewReader(b))
+}
+
+// Header of Matrix Market format
+const (
+	mmBanner = "%%matrixmarket"
 
-	// TODO: check vector
-	// TODO: check real
+	mmMatrix = "matrix"
+	mmVector = "vector"
 
-	// convert size of vector
-	s, err := strconv.ParseInt(string(lines[1]), 10, 64)
+	mmCoordinate = "coordinate"
+	mmArray      = "array"
+
+	mmReal    = "real"
+	mmInteger = "integer"
+	mmPattern = "pattern"
+
+	mmGeneral       = "general"
+	mmSymmetric     = "symmetric"
+	mmSkewSymmetric = "skew-symmetric"
+)
+
+// mmHeader is header of Matrix Market format
+type mmHeader struct {
+	object   string // matrix, vector
+	format   string // coordinate, array
+	field    string // real, integer, pattern
+	symmetry string // general, symmetric, skew-symmetric
+}
+
+// parseHeader returns header parsed from banner line
+//
+// Example:
+//
+//	%%MatrixMarket matrix coordinate real general
+func parseHeader(line string) (h mmHeader, err error) {
+	fields := strings.Fields(strings.ToLower(line))
+	if len(fields) != 5 || fields[0] != mmBanner {
+		err = fmt.Errorf("Not valid banner: `%s`", line)
+		return
+	}
+	h = mmHeader{
+		object:   fields[1],
+		format:   fields[2],
+		field:    fields[3],
+		symmetry: fields[4],
+	}
+
+	var et errors.Tree
+	et.Name = "Check header of Matrix Market format"
+	switch h.object {
+	case mmMatrix, mmVector:
+	default:
+		et.Add(fmt.Errorf("Not supported object: `%s`", h.object))
+	}
+	switch h.format {
+	case mmCoordinate, mmArray:
+	default:
+		et.Add(fmt.Errorf("Not supported format: `%s`", h.format))
+	}
+	switch h.field {
+	case mmReal, mmInteger:
+	case mmPattern:
+		if h.format == mmArray {
+			et.Add(fmt.Errorf("Field `%s` is not valid for format `%s`", h.field, h.format))
+		}
+	default:
+		et.Add(fmt.Errorf("Not supported field: `%s`", h.field))
+	}
+	switch h.symmetry {
+	case mmGeneral:
+	case mmSymmetric, mmSkewSymmetric:
+		if h.object == mmVector {
+			et.Add(fmt.Errorf("Symmetry `%s` is not valid for vector", h.symmetry))
+		}
+	default:
+		et.Add(fmt.Errorf("Not supported symmetry: `%s`", h.symmetry))
+	}
+	if et.IsError() {
+		err = et
+	}
+	return
+}
+
+// ReadMatrixMarket returns matrix read from reader in Matrix Market format.
+// See description:
+// https://math.nist.gov/MatrixMarket/formats.html
+//
+// Supported header values:
+//
+//	object   : matrix, vector
+//	format   : coordinate, array
+//	field    : real, integer, pattern
+//	symmetry : general, symmetric, skew-symmetric
+//
+// Lines with comments started from `%` are ignored. Value of pattern
+// matrix elements is 1.0.
+//
+// Result is *SparseMatrixSymmetric for symmetric matrix, otherwise
+// *SparseMatrix. Vector is returned as *mat.Dense with one column.
+//
+// Matrix of `lis` software with right-hand vector after matrix elements
+// is supported, vector is ignored.
+func ReadMatrixMarket(r io.Reader) (mat.Matrix, error) {
+	d, err := NewMatrixMarketDecoder(r)
 	if err != nil {
-		err = fmt.Errorf("Cannot parse size `%v`: %v", string(lines[1]), err)
 		return nil, err
 	}
 
-	v := mat.NewDense(int(s), 1, nil)
+	if d.h.object == mmVector {
+		return readVector(d)
+	}
+
+	var (
+		rows, cols = d.Dims()
+		sp         *SparseMatrix
+		sym        *SparseMatrixSymmetric
+	)
+	if d.Symmetry() == mmSymmetric {
+		sym = NewSparseMatrixSymmetric(rows)
+		sp = sym.s
+	} else {
+		sp = NewSparseMatrix(rows, cols)
+	}
 
-	// convert values
-	for i := range lines {
-		if i < 2 {
-			continue
+	for {
+		row, col, value, err := d.Next()
+		if err == io.EOF {
+			break
 		}
-		if len(bytes.TrimSpace(lines[i])) == 0 {
-			continue
-		}
-		pars := bytes.Split(lines[i], []byte(" "))
-
-		// parse index
-		s, err := strconv.ParseInt(string(pars[0]), 10, 64)
 		if err != nil {
-			err = fmt.Errorf("Cannot parse index `%v`: %v", string(pars[0]), err)
 			return nil, err
 		}
-		pos := int(s - 1) // in MatrixMarket index from 1, but not zero
 
-		// parse value
-		var val float64
-		for pos := 1; pos < len(pars); pos++ {
-			if len(pars[pos]) == 0 {
-				continue
+		switch d.Symmetry() {
+		case mmSymmetric:
+			if row > col {
+				row, col = col, row
 			}
-			s, err := strconv.ParseFloat(string(pars[pos]), 64)
-			if err != nil {
-				err = fmt.Errorf("Cannot parse value `%v`: %v", string(pars[pos]), err)
-				return nil, err
-			}
-			val = s
+			sym.Add(row, col, value)
+		case mmSkewSymmetric:
+			sp.Add(row, col, value)
+			sp.Add(col, row, -value)
+		default:
+			sp.Add(row, col, value)
 		}
-		v.Set(pos, 0, val)
 	}
 
+	if sym != nil {
+		return sym, nil
+	}
+	return sp, nil
+}
+
+// readVector returns vector with one column from decoder
+func readVector(d *MatrixMarketDecoder) (*mat.Dense, error) {
+	rows, _ := d.Dims()
+	v := mat.NewDense(rows, 1, nil)
+	for {
+		row, _, value, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		v.Set(row, 0, v.At(row, 0)+value)
+	}
 	return v, nil
 }
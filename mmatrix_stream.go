@@ -0,0 +1,351 @@
+package golis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// MatrixMarketDecoder reads entries of matrix or vector in Matrix Market
+// format one by one without storing all data in memory.
+// See description of ReadMatrixMarket.
+type MatrixMarketDecoder struct {
+	scanner *bufio.Scanner
+	line    int // number of last read line
+
+	h           mmHeader
+	rows, cols  int
+	amount      int  // amount of entries
+	read        int  // amount of read entries
+	coordinates bool // entries with indexes
+
+	ar, ac int // position of next element in array format
+
+	fields [][]byte // fields of last read line
+}
+
+// NewMatrixMarketDecoder returns decoder with parsed header and sizes
+// of Matrix Market data.
+func NewMatrixMarketDecoder(r io.Reader) (*MatrixMarketDecoder, error) {
+	d := &MatrixMarketDecoder{scanner: bufio.NewScanner(r)}
+	d.scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	// parse header
+	if !d.scanner.Scan() {
+		if err := d.scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Empty Matrix Market data")
+	}
+	d.line++
+	var err error
+	d.h, err = parseHeader(d.scanner.Text())
+	if err != nil {
+		return nil, d.lineError(err)
+	}
+
+	// parse sizes
+	fields, err := d.nextFields()
+	if err == io.EOF {
+		return nil, fmt.Errorf("Sizes of matrix are not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ints []int
+	for _, f := range fields {
+		v, err := parseInt(f)
+		if err != nil {
+			return nil, d.lineError(fmt.Errorf("Cannot parse size `%s`: %v", f, err))
+		}
+		if v < 0 {
+			return nil, d.lineError(fmt.Errorf("Size cannot be negative: %d", v))
+		}
+		ints = append(ints, v)
+	}
+
+	d.coordinates = d.h.format == mmCoordinate
+	switch {
+	case d.h.object == mmMatrix && d.coordinates && (len(ints) == 3 || len(ints) == 5):
+		// with 5 sizes is matrix with right-hand vector of `lis`
+		d.rows, d.cols, d.amount = ints[0], ints[1], ints[2]
+	case d.h.object == mmMatrix && !d.coordinates && len(ints) == 2:
+		d.rows, d.cols = ints[0], ints[1]
+		d.amount = d.rows * d.cols
+		switch d.h.symmetry {
+		case mmSymmetric:
+			d.amount = d.rows * (d.rows + 1) / 2
+		case mmSkewSymmetric:
+			d.amount = d.rows * (d.rows - 1) / 2
+			d.ar = 1
+		}
+	case d.h.object == mmVector && d.coordinates && (len(ints) == 1 || len(ints) == 2):
+		d.rows, d.cols, d.amount = ints[0], 1, ints[0]
+		if len(ints) == 2 {
+			d.amount = ints[1]
+		}
+	case d.h.object == mmVector && !d.coordinates && len(ints) == 1:
+		d.rows, d.cols, d.amount = ints[0], 1, ints[0]
+	default:
+		return nil, d.lineError(fmt.Errorf("Not valid sizes: %q", fields))
+	}
+	if d.rows == 0 || d.cols == 0 {
+		return nil, d.lineError(fmt.Errorf("Sizes cannot be zero: %q", fields))
+	}
+	if d.h.symmetry != mmGeneral && d.rows != d.cols {
+		return nil, d.lineError(fmt.Errorf("Matrix `%s` is not square: [%d,%d]",
+			d.h.symmetry, d.rows, d.cols))
+	}
+	return d, nil
+}
+
+// Dims returns the dimensions of matrix.
+// Vector have one column.
+func (d *MatrixMarketDecoder) Dims() (r, c int) {
+	return d.rows, d.cols
+}
+
+// Len returns amount of entries in data.
+func (d *MatrixMarketDecoder) Len() int {
+	return d.amount
+}
+
+// Symmetry returns symmetry of matrix from header:
+// general, symmetric, skew-symmetric.
+func (d *MatrixMarketDecoder) Symmetry() string {
+	return d.h.symmetry
+}
+
+// Next returns next entry with zero-based indexes as it is stored
+// in data without symmetric elements. For vector column is zero.
+// After last entry error io.EOF is returned.
+func (d *MatrixMarketDecoder) Next() (r, c int, value float64, err error) {
+	if d.read >= d.amount {
+		err = io.EOF
+		return
+	}
+	fields, err := d.nextFields()
+	if err == io.EOF {
+		err = fmt.Errorf("Not enough entries: %d of %d", d.read, d.amount)
+		return
+	}
+	if err != nil {
+		return
+	}
+	d.read++
+
+	// indexes
+	switch {
+	case d.coordinates && d.h.object == mmMatrix:
+		if len(fields) < 2 {
+			err = d.lineError(fmt.Errorf("Not valid entry: %q", fields))
+			return
+		}
+		if r, err = d.parseIndex(fields[0], d.rows); err != nil {
+			return
+		}
+		if c, err = d.parseIndex(fields[1], d.cols); err != nil {
+			return
+		}
+		fields = fields[2:]
+	case d.coordinates && d.h.object == mmVector:
+		if r, err = d.parseIndex(fields[0], d.rows); err != nil {
+			return
+		}
+		fields = fields[1:]
+	default:
+		// array format in column-major order
+		if d.ar >= d.rows {
+			d.ac++
+			d.ar = 0
+			switch d.h.symmetry {
+			case mmSymmetric:
+				d.ar = d.ac
+			case mmSkewSymmetric:
+				d.ar = d.ac + 1
+			}
+		}
+		r, c = d.ar, d.ac
+		d.ar++
+	}
+	if d.h.symmetry == mmSkewSymmetric && r == c {
+		err = d.lineError(fmt.Errorf(
+			"Diagonal element of skew-symmetric matrix: %d", r+1))
+		return
+	}
+
+	// value
+	if d.h.field == mmPattern {
+		if len(fields) != 0 {
+			err = d.lineError(fmt.Errorf("Not valid pattern entry: %q", fields))
+			return
+		}
+		value = 1.0
+		return
+	}
+	if len(fields) != 1 {
+		err = d.lineError(fmt.Errorf("Not valid entry: %q", fields))
+		return
+	}
+	if value, err = strconv.ParseFloat(string(fields[0]), 64); err != nil {
+		err = d.lineError(fmt.Errorf("Cannot parse value `%s`: %v", fields[0], err))
+		return
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		err = d.lineError(fmt.Errorf("Value is not valid: %v", value))
+		return
+	}
+	return
+}
+
+// nextFields returns fields of next line with data. Empty lines and
+// lines with comments are ignored. Fields are valid until next call.
+func (d *MatrixMarketDecoder) nextFields() ([][]byte, error) {
+	for d.scanner.Scan() {
+		d.line++
+		b := d.scanner.Bytes()
+		if len(b) > 0 && b[0] == '%' {
+			continue
+		}
+		d.fields = splitFields(d.fields[:0], b)
+		if len(d.fields) == 0 {
+			continue
+		}
+		return d.fields, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (d *MatrixMarketDecoder) lineError(err error) error {
+	return fmt.Errorf("Line %d: %v", d.line, err)
+}
+
+// parseIndex returns zero-based index parsed from one-based index of
+// Matrix Market format
+func (d *MatrixMarketDecoder) parseIndex(b []byte, size int) (int, error) {
+	index, err := parseInt(b)
+	if err != nil {
+		return 0, d.lineError(fmt.Errorf("Cannot parse index `%s`: %v", b, err))
+	}
+	if index < 1 || size < index {
+		return 0, d.lineError(fmt.Errorf("Index is outside of matrix: %d of %d", index, size))
+	}
+	return index - 1, nil // in MatrixMarket index from 1, but not zero
+}
+
+// splitFields appends to fields the fields of line separated by spaces
+// or tabs. Fields are slices of line.
+func splitFields(fields [][]byte, b []byte) [][]byte {
+	for i := 0; i < len(b); {
+		for i < len(b) && (b[i] == ' ' || b[i] == '\t' || b[i] == '\r') {
+			i++
+		}
+		start := i
+		for i < len(b) && !(b[i] == ' ' || b[i] == '\t' || b[i] == '\r') {
+			i++
+		}
+		if start < i {
+			fields = append(fields, b[start:i])
+		}
+	}
+	return fields
+}
+
+// parseInt returns integer parsed from decimal digits without
+// memory allocation
+func parseInt(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, fmt.Errorf("Empty integer")
+	}
+	sign := 1
+	switch b[0] {
+	case '-':
+		sign = -1
+		b = b[1:]
+	case '+':
+		b = b[1:]
+	}
+	if len(b) == 0 || len(b) > 18 {
+		return 0, fmt.Errorf("Not valid integer: `%s`", b)
+	}
+	var v int
+	for _, ch := range b {
+		if ch < '0' || '9' < ch {
+			return 0, fmt.Errorf("Not valid integer: `%s`", b)
+		}
+		v = v*10 + int(ch-'0')
+	}
+	return sign * v, nil
+}
+
+// MatrixMarketEncoder writes matrix or vector in coordinate real
+// Matrix Market format entry by entry without storing all data in memory.
+type MatrixMarketEncoder struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+// NewMatrixMarketEncoder returns encoder with buffered writer.
+// Data is written in w only after Flush.
+func NewMatrixMarketEncoder(w io.Writer) *MatrixMarketEncoder {
+	return &MatrixMarketEncoder{
+		w:   bufio.NewWriterSize(w, 64*1024),
+		buf: make([]byte, 0, 64),
+	}
+}
+
+// WriteHeader writes banner and line of sizes.
+// Object is "matrix" or "vector", symmetry is "general" or "symmetric".
+//
+// Example:
+//
+//	%%MatrixMarket matrix coordinate real general
+//	3 3 5
+func (e *MatrixMarketEncoder) WriteHeader(object, symmetry string, sizes ...int) error {
+	e.buf = append(e.buf[:0], "%%MatrixMarket "...)
+	e.buf = append(e.buf, object...)
+	e.buf = append(e.buf, " coordinate real "...)
+	e.buf = append(e.buf, symmetry...)
+	e.buf = append(e.buf, '\n')
+	for i, s := range sizes {
+		if i > 0 {
+			e.buf = append(e.buf, ' ')
+		}
+		e.buf = strconv.AppendInt(e.buf, int64(s), 10)
+	}
+	e.buf = append(e.buf, '\n')
+	_, err := e.w.Write(e.buf)
+	return err
+}
+
+// WriteEntry writes matrix entry with zero-based indexes.
+func (e *MatrixMarketEncoder) WriteEntry(r, c int, value float64) error {
+	e.buf = strconv.AppendInt(e.buf[:0], int64(r+1), 10)
+	e.buf = append(e.buf, ' ')
+	e.buf = strconv.AppendInt(e.buf, int64(c+1), 10)
+	e.buf = append(e.buf, ' ')
+	e.buf = strconv.AppendFloat(e.buf, value, 'e', 16, 64)
+	e.buf = append(e.buf, '\n')
+	_, err := e.w.Write(e.buf)
+	return err
+}
+
+// WriteVectorEntry writes vector entry with zero-based index.
+func (e *MatrixMarketEncoder) WriteVectorEntry(i int, value float64) error {
+	e.buf = strconv.AppendInt(e.buf[:0], int64(i+1), 10)
+	e.buf = append(e.buf, ' ')
+	e.buf = strconv.AppendFloat(e.buf, value, 'e', 16, 64)
+	e.buf = append(e.buf, '\n')
+	_, err := e.w.Write(e.buf)
+	return err
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (e *MatrixMarketEncoder) Flush() error {
+	return e.w.Flush()
+}
@@ -0,0 +1,199 @@
+package golis_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMatrixMarketDecoder(t *testing.T) {
+	input := `%%MatrixMarket matrix coordinate real symmetric
+% comment
+3 3 3
+1 1 8
+	3  1	 6
+3 3 2
+`
+	d, err := golis.NewMatrixMarketDecoder(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, c := d.Dims(); r != 3 || c != 3 {
+		t.Errorf("Not valid sizes: [%d,%d]", r, c)
+	}
+	if d.Len() != 3 || d.Symmetry() != "symmetric" {
+		t.Errorf("Not valid header: %d %s", d.Len(), d.Symmetry())
+	}
+
+	type entry struct {
+		r, c int
+		v    float64
+	}
+	var entries []entry
+	for {
+		r, c, v, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry{r, c, v})
+	}
+	expect := []entry{{0, 0, 8}, {2, 0, 6}, {2, 2, 2}}
+	if fmt.Sprint(entries) != fmt.Sprint(expect) {
+		t.Errorf("Not same entries: %v", entries)
+	}
+	if _, _, _, err := d.Next(); err != io.EOF {
+		t.Errorf("Not valid error after last entry: %v", err)
+	}
+}
+
+func TestMatrixMarketEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	e := golis.NewMatrixMarketEncoder(&buf)
+	if err := e.WriteHeader("vector", "general", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WriteVectorEntry(0, -5.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WriteVectorEntry(1, 0.25); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Data is written before flush")
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expect := `%%MatrixMarket vector coordinate real general
+2
+1 -5.5000000000000000e+00
+2 2.5000000000000000e-01
+`
+	if buf.String() != expect {
+		t.Fatalf("Not same:\n%s\n%s", buf.String(), expect)
+	}
+}
+
+// bandMatrix returns sparse matrix with band of non-zero elements
+func bandMatrix(size, band int) *golis.SparseMatrix {
+	s := golis.NewSparseMatrix(size, size)
+	for i := 0; i < size; i++ {
+		for j := i - band; j <= i+band; j++ {
+			if 0 <= j && j < size {
+				s.Add(i, j, float64(i+1)/float64(j+3))
+			}
+		}
+	}
+	return s
+}
+
+// bandVector returns vector with non-zero values
+func bandVector(size int) *mat.Dense {
+	v := mat.NewDense(size, 1, nil)
+	for i := 0; i < size; i++ {
+		v.Set(i, 0, float64(i+1)/3.0)
+	}
+	return v
+}
+
+func BenchmarkWriteMatrixMarket(b *testing.B) {
+	s := bandMatrix(20000, 5)
+	v := bandVector(20000)
+	size := int64(len(golis.ConvertMatrixWithVectorBaseline(s, v)))
+
+	b.Run("Baseline", func(b *testing.B) {
+		b.SetBytes(size)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ioutil.Discard.Write(golis.ConvertMatrixWithVectorBaseline(s, v))
+		}
+	})
+	b.Run("Stream", func(b *testing.B) {
+		b.SetBytes(size)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := golis.WriteMatrixMarketWithVector(ioutil.Discard, s, v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkReadMatrixMarket(b *testing.B) {
+	// vector is result of `lis` software
+	var vector bytes.Buffer
+	e := golis.NewMatrixMarketEncoder(&vector)
+	size := 200000
+	if err := e.WriteHeader("vector", "general", size); err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < size; i++ {
+		if err := e.WriteVectorEntry(i, float64(i+1)/3.0); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := e.Flush(); err != nil {
+		b.Fatal(err)
+	}
+
+	var matrix bytes.Buffer
+	if err := golis.WriteMatrixMarket(&matrix, bandMatrix(20000, 5)); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("VectorBaseline", func(b *testing.B) {
+		b.SetBytes(int64(vector.Len()))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := golis.ParseSparseMatrixBaseline(vector.Bytes()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("VectorStream", func(b *testing.B) {
+		b.SetBytes(int64(vector.Len()))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := golis.ReadMatrixMarket(bytes.NewReader(vector.Bytes())); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("MatrixStream", func(b *testing.B) {
+		b.SetBytes(int64(matrix.Len()))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := golis.ReadMatrixMarket(bytes.NewReader(matrix.Bytes())); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("MatrixDecoder", func(b *testing.B) {
+		b.SetBytes(int64(matrix.Len()))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d, err := golis.NewMatrixMarketDecoder(bytes.NewReader(matrix.Bytes()))
+			if err != nil {
+				b.Fatal(err)
+			}
+			for {
+				_, _, _, err := d.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
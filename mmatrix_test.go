@@ -0,0 +1,388 @@
+package golis_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		input  string
+		result *mat.Dense
+		isSym  bool
+	}{
+		{
+			name: "MatrixCoordinateGeneral",
+			input: `%%MatrixMarket matrix coordinate real general
+% comment
+%
+3 2 4
+
+1 1 1.5
+3 1 -2
+2 2 4.0e+00
+1 2 1`,
+			result: mat.NewDense(3, 2, []float64{
+				1.5, 1,
+				0, 4,
+				-2, 0,
+			}),
+		},
+		{
+			name: "MatrixCoordinateSymmetric",
+			input: `%%MatrixMarket matrix coordinate real symmetric
+3 3 4
+1 1 8
+2 1 1
+3 2 7
+3 3 2`,
+			result: mat.NewDense(3, 3, []float64{
+				8, 1, 0,
+				1, 0, 7,
+				0, 7, 2,
+			}),
+			isSym: true,
+		},
+		{
+			name: "MatrixCoordinateSkewSymmetric",
+			input: `%%MatrixMarket matrix coordinate real skew-symmetric
+2 2 1
+2 1 3`,
+			result: mat.NewDense(2, 2, []float64{
+				0, -3,
+				3, 0,
+			}),
+		},
+		{
+			name: "MatrixCoordinatePattern",
+			input: `%%MatrixMarket matrix coordinate pattern general
+2 2 2
+1 2
+2 1`,
+			result: mat.NewDense(2, 2, []float64{
+				0, 1,
+				1, 0,
+			}),
+		},
+		{
+			name: "MatrixCoordinateInteger",
+			input: `%%MatrixMarket MATRIX Coordinate Integer General
+2 2 2
+1 1 5
+2 2 -6`,
+			result: mat.NewDense(2, 2, []float64{
+				5, 0,
+				0, -6,
+			}),
+		},
+		{
+			name: "MatrixArrayGeneral",
+			input: `%%MatrixMarket matrix array real general
+2 3
+1
+2
+3
+4
+5
+6`,
+			result: mat.NewDense(2, 3, []float64{
+				1, 3, 5,
+				2, 4, 6,
+			}),
+		},
+		{
+			name: "MatrixArraySymmetric",
+			input: `%%MatrixMarket matrix array real symmetric
+3 3
+1
+2
+3
+4
+5
+6`,
+			result: mat.NewDense(3, 3, []float64{
+				1, 2, 3,
+				2, 4, 5,
+				3, 5, 6,
+			}),
+			isSym: true,
+		},
+		{
+			name: "MatrixArraySkewSymmetric",
+			input: `%%MatrixMarket matrix array real skew-symmetric
+3 3
+1
+2
+3`,
+			result: mat.NewDense(3, 3, []float64{
+				0, -1, -2,
+				1, 0, -3,
+				2, 3, 0,
+			}),
+		},
+		{
+			name: "VectorCoordinate",
+			input: `%%MatrixMarket vector coordinate real general
+3
+1  -5.5
+2   2.5
+3   5.0`,
+			result: mat.NewDense(3, 1, []float64{-5.5, 2.5, 5.0}),
+		},
+		{
+			name: "VectorCoordinateWithAmount",
+			input: `%%MatrixMarket vector coordinate real general
+3 1
+2   2.5`,
+			result: mat.NewDense(3, 1, []float64{0, 2.5, 0}),
+		},
+		{
+			name: "VectorArray",
+			input: `%%MatrixMarket vector array real general
+2
+7
+8
+`,
+			result: mat.NewDense(2, 1, []float64{7, 8}),
+		},
+		{
+			name: "MatrixWithVectorLis",
+			input: `%%MatrixMarket matrix coordinate real general
+2 2 2 1 0
+1 1 1
+2 2 2
+1 5
+2 6`,
+			result: mat.NewDense(2, 2, []float64{
+				1, 0,
+				0, 2,
+			}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := golis.ReadMatrixMarket(strings.NewReader(tc.input))
+			if err != nil {
+				t.Fatalf("Cannot read : %v", err)
+			}
+			r, c := m.Dims()
+			if er, ec := tc.result.Dims(); r != er || c != ec {
+				t.Fatalf("Not valid sizes: [%d,%d]", r, c)
+			}
+			if !isSame(m, tc.result) {
+				t.Fatalf("Value is not same:\n%v\n%v", m, mat.Formatted(tc.result))
+			}
+			_, isSym := m.(*golis.SparseMatrixSymmetric)
+			if isSym != tc.isSym {
+				t.Errorf("Not valid type of matrix: %T", m)
+			}
+			_, isDense := m.(*mat.Dense)
+			if isDense != strings.HasPrefix(tc.name, "Vector") {
+				t.Errorf("Not valid type of matrix: %T", m)
+			}
+		})
+	}
+}
+
+func TestReadMatrixMarketFail(t *testing.T) {
+	for i, input := range []string{
+		"",
+		"%%MatrixMarket matrix coordinate real",
+		"%MatrixMarket matrix coordinate real general\n1 1 1\n1 1 1",
+		"%%MatrixMarket tensor coordinate real general\n1 1 1\n1 1 1",
+		"%%MatrixMarket matrix diagonal real general\n1 1 1\n1 1 1",
+		"%%MatrixMarket matrix coordinate complex general\n1 1 1\n1 1 1 0",
+		"%%MatrixMarket matrix coordinate real hermitian\n1 1 1\n1 1 1",
+		"%%MatrixMarket matrix array pattern general\n1 1\n1",
+		"%%MatrixMarket vector coordinate real symmetric\n1\n1 1",
+		"%%MatrixMarket matrix coordinate real general",
+		"%%MatrixMarket matrix coordinate real general\n1 1",
+		"%%MatrixMarket matrix coordinate real general\n0 1 0",
+		"%%MatrixMarket matrix coordinate real general\n-1 1 0",
+		"%%MatrixMarket matrix coordinate real general\na 1 0",
+		"%%MatrixMarket matrix coordinate real symmetric\n2 3 0",
+		"%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 0 1",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\na 1 1",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1 a",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1 NaN",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1 1 1",
+		"%%MatrixMarket matrix coordinate pattern general\n2 2 1\n1 1 1",
+		"%%MatrixMarket matrix coordinate real skew-symmetric\n2 2 1\n1 1 1",
+		"%%MatrixMarket vector coordinate real general\n2\n3 1",
+	} {
+		t.Run(fmt.Sprintf("Fail%d", i), func(t *testing.T) {
+			_, err := golis.ReadMatrixMarket(strings.NewReader(input))
+			t.Logf("\n%v", err)
+			if err == nil {
+				t.Fatalf("Haven`t error : %v", err)
+			}
+		})
+	}
+}
+
+func TestWriteMatrixMarket(t *testing.T) {
+	sym := golis.NewSparseMatrixSymmetric(3)
+	sym.Add(0, 0, 8)
+	sym.Add(0, 1, 1)
+	sym.Add(1, 2, 7)
+	sym.Add(2, 2, 2)
+
+	sp := golis.NewSparseMatrix(3, 2)
+	sp.Add(0, 0, 1.5)
+	sp.Add(2, 0, -2)
+	sp.Add(1, 1, 4)
+
+	symDense := mat.NewSymDense(2, []float64{
+		1, 2,
+		2, 3,
+	})
+
+	dense := mat.NewDense(2, 2, []float64{
+		1, 0,
+		0, 2,
+	})
+
+	// pattern with zero value in slot
+	patternZero := sp.Pattern()
+	if err := patternZero.Set(1, 1, 0.0); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		m      mat.Matrix
+		header string
+	}{
+		{"SparseMatrixSymmetric", sym, "%%MatrixMarket matrix coordinate real symmetric\n3 3 4\n"},
+		{"SparseMatrix", sp, "%%MatrixMarket matrix coordinate real general\n3 2 3\n"},
+		{"SymDense", symDense, "%%MatrixMarket matrix coordinate real symmetric\n2 2 3\n"},
+		{"Dense", dense, "%%MatrixMarket matrix coordinate real general\n2 2 2\n"},
+		{"PatternSymmetric", sym.Pattern(), "%%MatrixMarket matrix coordinate real symmetric\n3 3 4\n"},
+		{"Pattern", sp.Pattern(), "%%MatrixMarket matrix coordinate real general\n3 2 3\n"},
+		{"PatternZero", patternZero, "%%MatrixMarket matrix coordinate real general\n3 2 2\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf strings.Builder
+			if err := golis.WriteMatrixMarket(&buf, tc.m); err != nil {
+				t.Fatal(err)
+			}
+			out := buf.String()
+			if !strings.HasPrefix(out, tc.header) {
+				t.Fatalf("Not valid header:\n%s", out)
+			}
+
+			// all indexes of symmetric matrix in lower triangle
+			if strings.Contains(tc.header, "symmetric") {
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				for _, line := range lines[2:] {
+					var r, c int
+					if _, err := fmt.Sscanf(line, "%d %d", &r, &c); err != nil {
+						t.Fatal(err)
+					}
+					if r < c {
+						t.Errorf("Element is not in lower triangle: %s", line)
+					}
+				}
+			}
+
+			m, err := golis.ReadMatrixMarket(strings.NewReader(out))
+			if err != nil {
+				t.Fatalf("Cannot read : %v\n%s", err, out)
+			}
+			if !isSame(m, tc.m) {
+				t.Fatalf("Value is not same:\n%v\n%v", m, out)
+			}
+		})
+	}
+
+	t.Run("WithVector", func(t *testing.T) {
+		b := mat.NewDense(3, 1, []float64{5, 0, 6})
+		var buf strings.Builder
+		if err := golis.WriteMatrixMarketWithVector(&buf, sp, b); err != nil {
+			t.Fatal(err)
+		}
+		expect := `%%MatrixMarket matrix coordinate real general
+3 2 3 1 0
+1 1 1.5000000000000000e+00
+3 1 -2.0000000000000000e+00
+2 2 4.0000000000000000e+00
+1 5.0000000000000000e+00
+2 0.0000000000000000e+00
+3 6.0000000000000000e+00
+`
+		if buf.String() != expect {
+			t.Fatalf("Not same:\n%s\n%s", buf.String(), expect)
+		}
+	})
+
+	t.Run("WithVectorFail", func(t *testing.T) {
+		var buf strings.Builder
+		err := golis.WriteMatrixMarketWithVector(&buf, sp, mat.NewDense(2, 1, nil))
+		if err == nil {
+			t.Fatalf("Haven`t error : %v", err)
+		}
+	})
+
+	t.Run("WithVectors", func(t *testing.T) {
+		b := mat.NewDense(3, 1, []float64{5, 0, 6})
+		x0 := mat.NewDense(3, 1, []float64{1, 2, 3})
+		var buf strings.Builder
+		if err := golis.WriteMatrixMarketWithVectors(&buf, sp, b, x0); err != nil {
+			t.Fatal(err)
+		}
+		expect := `%%MatrixMarket matrix coordinate real general
+3 2 3 1 1
+1 1 1.5000000000000000e+00
+3 1 -2.0000000000000000e+00
+2 2 4.0000000000000000e+00
+1 5.0000000000000000e+00
+2 0.0000000000000000e+00
+3 6.0000000000000000e+00
+1 1.0000000000000000e+00
+2 2.0000000000000000e+00
+3 3.0000000000000000e+00
+`
+		if buf.String() != expect {
+			t.Fatalf("Not same:\n%s\n%s", buf.String(), expect)
+		}
+
+		// matrix is read without vectors
+		m, err := golis.ReadMatrixMarket(strings.NewReader(buf.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isSame(m, sp) {
+			t.Fatalf("Value is not same:\n%v", m)
+		}
+
+		err = golis.WriteMatrixMarketWithVectors(&buf, sp, b, mat.NewDense(3, 2, nil))
+		if err == nil {
+			t.Fatalf("Haven`t error : %v", err)
+		}
+		err = golis.WriteMatrixMarketWithVectors(&buf, sp, b, (*mat.Dense)(nil))
+		if err == nil {
+			t.Fatalf("Haven`t error for nil x0 : %v", err)
+		}
+	})
+
+	t.Run("WriterFail", func(t *testing.T) {
+		err := golis.WriteMatrixMarket(failWriter{}, sp)
+		if err == nil {
+			t.Fatalf("Haven`t error : %v", err)
+		}
+	})
+}
+
+// failWriter is writer with error for any writing
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) {
+	return 0, fmt.Errorf("Cannot write")
+}
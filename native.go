@@ -0,0 +1,326 @@
+package golis
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// matVec is matrix with operation of multiplication on vector y = A * x
+type matVec interface {
+	mulVec(y, x []float64)
+}
+
+// guarantee sparse matrixes have multiplication on vector
+var (
+	_ matVec = (*SparseMatrix)(nil)
+	_ matVec = (*SparseMatrixSymmetric)(nil)
+	_ matVec = (*CSR)(nil)
+	_ matVec = (*CSC)(nil)
+	_ matVec = (*PatternMatrix)(nil)
+)
+
+// LsolveNative returns solution matrix of iterative solve for linear system
+// without external `lis` software. Arguments and results are same as
+// in function Lsolve, so call of Lsolve can be replaced without changes
+// of options.
+//
+//	A * x = b
+//
+// Where: A is matrix, b is right-hand vector. If b have several columns,
+// then results are same as in function Lsolve, see LsolveNativeMultiple.
+//
+// Supported options (defaults in brackets):
+//
+//	-i       solver: cg, bicgstab, gmres [bicgstab]
+//	-maxiter maximal amount of iterations  [1000]
+//	-tol     convergence tolerance         [1e-12]
+//	-restart restart value for GMRES       [40]
+//	-initx_zeros zero initial guess        [true]
+//	-p       preconditioner: none, jacobi, ssor, ilu, ic [none]
+//	-ssor_omega relaxation of SSOR         [1.0]
+//	-ilu_fill fill level of ILU: 0         [0]
+//	-f       precision: double             [double]
+//
+// Solver CG is used only for symmetric matrixes: types with interface
+// mat.Symmetric, for example SparseMatrixSymmetric, or symmetric
+// PatternMatrix. For other matrixes error IllOption is returned.
+//
+// Other options of `lis` software return error NotImplemented,
+// not valid values of options return error IllOption.
+// For user defined preconditioner, see NativeSolver.
+//
+// If solver is failed with error Maxiter or Breakdown, then residual
+// history and output with status of solver are returned with error.
+func LsolveNative(A, b mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return NativeSolver{}.SolveInitial(A, b, nil, options)
+}
+
+// LsolveNativeInitial is same as LsolveNative, but with initial guess x0.
+// See description of LsolveInitial. First value of residual history is
+// relative residual ||b-A*x0||/||b|| of initial guess x0.
+func LsolveNativeInitial(A, b, x0 mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return NativeSolver{}.SolveInitial(A, b, x0, options)
+}
+
+// LsolveNativeMultiple returns solution matrix of iterative solve for
+// linear systems with same matrix A and several right-hand vectors
+// without external `lis` software. Options are parsed and matrix A with
+// preconditioner are prepared only once. See description of
+// LsolveMultiple and LsolveNative.
+func LsolveNativeMultiple(A, B mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory [][]float64,
+	output []string,
+	err error) {
+	return NativeSolver{}.SolveMultiple(A, B, options)
+}
+
+// solve returns solution of linear system by in-process solver with
+// preconditioner M. Initial guess x0 is used only with option
+// "-initx_zeros false".
+func (opt nativeOptions) solve(A matVec, b, x0 []float64, M Preconditioner) (
+	x, rhistory []float64, err error) {
+	if opt.initxZeros {
+		x0 = nil
+	}
+	switch opt.solver {
+	case "cg":
+		return cg(A, b, x0, M, opt.tol, opt.maxiter)
+	case "bicgstab":
+		return bicgstab(A, b, x0, M, opt.tol, opt.maxiter)
+	case "gmres":
+		return gmres(A, b, x0, M, opt.restart, opt.tol, opt.maxiter)
+	}
+	return nil, nil, NotImplemented
+}
+
+// preconditioner returns preconditioner of matrix A by options
+// and name of preconditioner in `lis` output
+func (opt nativeOptions) preconditioner(A mat.Matrix) (
+	M Preconditioner, name string, err error) {
+	switch opt.precond {
+	case "jacobi":
+		M, err = NewJacobi(A)
+	case "ssor":
+		M, err = NewSSOR(A, opt.omega)
+	case "ilu":
+		M, err = NewILU0(A)
+	case "ic":
+		M, err = NewIC0(A)
+	default:
+		return nil, "none", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return M, nativePrecondNames[opt.precond], nil
+}
+
+// nativeOptions is options of in-process solvers
+type nativeOptions struct {
+	solver     string
+	maxiter    int
+	tol        float64
+	restart    int
+	initxZeros bool    // initial guess is zero
+	precond    string  // name of preconditioner, empty if not defined
+	omega      float64 // relaxation of SSOR preconditioner
+}
+
+// nativeSolverNames is names of in-process solvers in `lis` output
+var nativeSolverNames = map[string]string{
+	"cg":       "CG",
+	"bicgstab": "BiCGSTAB",
+	"gmres":    "GMRES",
+}
+
+// nativePrecondNames is names of in-process preconditioners in `lis` output
+var nativePrecondNames = map[string]string{
+	"none":   "none",
+	"jacobi": "Jacobi",
+	"ssor":   "SSOR",
+	"ilu":    "ILU(0)",
+	"ic":     "IC(0)",
+}
+
+// parseNativeOptions returns options of in-process solvers parsed from
+// string in `lis` syntax
+func parseNativeOptions(options string) (opt nativeOptions, err error) {
+	opt = nativeOptions{
+		solver:     "bicgstab",
+		maxiter:    1000,
+		tol:        1e-12,
+		restart:    40,
+		initxZeros: true,
+		omega:      1.0,
+	}
+
+	fields := strings.Fields(options)
+	if len(fields)%2 != 0 {
+		err = IllOption
+		return
+	}
+	for i := 0; i < len(fields); i += 2 {
+		name, value := fields[i], fields[i+1]
+		switch name {
+		case "-i":
+			if _, ok := nativeSolverNames[value]; !ok {
+				err = NotImplemented
+				return
+			}
+			opt.solver = value
+		case "-maxiter":
+			opt.maxiter, err = strconv.Atoi(value)
+			if err != nil || opt.maxiter <= 0 {
+				err = IllOption
+				return
+			}
+		case "-tol":
+			opt.tol, err = strconv.ParseFloat(value, 64)
+			if err != nil || !(opt.tol > 0.0) || math.IsInf(opt.tol, 0) {
+				err = IllOption
+				return
+			}
+		case "-restart":
+			opt.restart, err = strconv.Atoi(value)
+			if err != nil || opt.restart <= 0 {
+				err = IllOption
+				return
+			}
+		case "-initx_zeros":
+			switch value {
+			case "true", "1":
+				opt.initxZeros = true
+			case "false", "0":
+				opt.initxZeros = false
+			default:
+				err = IllOption
+				return
+			}
+		case "-p":
+			if _, ok := nativePrecondNames[value]; !ok {
+				err = NotImplemented
+				return
+			}
+			opt.precond = value
+		case "-ssor_omega":
+			opt.omega, err = strconv.ParseFloat(value, 64)
+			if err != nil || !(0 < opt.omega && opt.omega < 2) {
+				err = IllOption
+				return
+			}
+		case "-ilu_fill":
+			var fill int
+			fill, err = strconv.Atoi(value)
+			if err != nil || fill < 0 {
+				err = IllOption
+				return
+			}
+			if fill != 0 {
+				err = NotImplemented
+				return
+			}
+		case "-f":
+			if value != "double" {
+				err = NotImplemented
+				return
+			}
+		default:
+			err = NotImplemented
+			return
+		}
+	}
+	return
+}
+
+// nativeOutput returns report of in-process solver in format of
+// `lsolve` output. Status of solver is created by error of solver.
+func nativeOutput(opt nativeOptions, size int, rhistory []float64,
+	precond string, ptime, elapsed time.Duration, status error) string {
+
+	name := nativeSolverNames[opt.solver]
+	iters := len(rhistory) - 1
+	if iters < 0 {
+		iters = 0
+	}
+	var resid float64
+	if len(rhistory) > 0 {
+		resid = rhistory[len(rhistory)-1]
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "number of threads = 1\n")
+	fmt.Fprintf(&buf, "matrix size = %d x %d\n\n", size, size)
+	if opt.initxZeros {
+		fmt.Fprintf(&buf, "initial vector x      : all components set to 0\n")
+	} else {
+		fmt.Fprintf(&buf, "initial vector x      : user defined\n")
+	}
+	fmt.Fprintf(&buf, "precision             : double\n")
+	fmt.Fprintf(&buf, "linear solver         : %s\n", name)
+	fmt.Fprintf(&buf, "preconditioner        : %s\n", precond)
+	fmt.Fprintf(&buf, "convergence condition : ||b-Ax||_2 <= %.1e * ||b||_2\n", opt.tol)
+	fmt.Fprintf(&buf, "matrix storage format : triple\n")
+	fmt.Fprintf(&buf, "linear solver status  : %s\n\n", nativeStatus(status))
+	fmt.Fprintf(&buf, "%s: number of iterations = %d\n", name, iters)
+	fmt.Fprintf(&buf, "%s: elapsed time         = %e sec.\n", name, (ptime + elapsed).Seconds())
+	fmt.Fprintf(&buf, "%s:   preconditioner     = %e sec.\n", name, ptime.Seconds())
+	fmt.Fprintf(&buf, "%s:   linear solver      = %e sec.\n", name, elapsed.Seconds())
+	fmt.Fprintf(&buf, "%s: relative residual    = %e\n", name, resid)
+	return buf.String()
+}
+
+// nativeStatus returns status of solver in format of `lsolve` output
+//
+// Example:
+//
+//	normal end
+//	LIS_MAXITER(code=4)
+func nativeStatus(err error) string {
+	if err == nil {
+		return "normal end"
+	}
+	if ev, ok := err.(ErrorValue); ok {
+		return fmt.Sprintf("%s(code=%d)", errorStrings[int(ev)], int(ev)+1)
+	}
+	return err.Error()
+}
+
+// isSymmetric returns true, if type of matrix guarantees symmetry
+func isSymmetric(A mat.Matrix) bool {
+	switch v := A.(type) {
+	case mat.Symmetric:
+		return true
+	case *PatternMatrix:
+		return v.IsSymmetric()
+	}
+	return false
+}
+
+// convertToSparse returns sparse matrix with non-zero values of matrix
+func convertToSparse(A mat.Matrix) *SparseMatrix {
+	r, c := A.Dims()
+	s := NewSparseMatrix(r, c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if v := A.At(i, j); v != 0.0 {
+				s.Add(i, j, v)
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,297 @@
+package golis_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+// convectionDiffusion returns non-symmetric sparse matrix
+func convectionDiffusion(size int) *golis.SparseMatrix {
+	A := golis.NewSparseMatrix(size, size)
+	for i := 0; i < size; i++ {
+		A.Add(i, i, 4.0)
+		if i > 0 {
+			A.Add(i, i-1, -1.5)
+		}
+		if i+1 < size {
+			A.Add(i, i+1, -0.5)
+		}
+	}
+	return A
+}
+
+func TestLsolveNative(t *testing.T) {
+	A := mat.NewDense(2, 2, []float64{
+		1.0, 2.0,
+		4.0, 1.0,
+	})
+	b := mat.NewDense(2, 1, []float64{
+		4.0,
+		9.0,
+	})
+
+	options := []string{
+		"",
+		"-f double",
+		"-p none",
+		"-i bicgstab",
+		"-i bicgstab -maxiter 20000",
+		"-i bicgstab  -tol 1e-14",
+		"-i gmres",
+		"-i gmres -restart 20",
+		"-i gmres -restart 2 -maxiter 20000",
+	}
+
+	for _, opt := range options {
+		t.Run(fmt.Sprintf("Option%s", opt), func(t *testing.T) {
+			s, rhistory, output, err := golis.LsolveNative(A, b, opt)
+			if err != nil {
+				t.Fatalf("Not correct result: %v", err)
+			}
+			if len(rhistory) == 0 || len(output) == 0 {
+				t.Errorf("Not valid report: %v\n%s", rhistory, output)
+			}
+
+			if math.Abs(s.At(0, 0)-2) >= 1e-10 {
+				t.Errorf("Element 0,0 is not correct : %v", s.At(0, 0))
+			}
+			if math.Abs(s.At(1, 0)-1) >= 1e-10 {
+				t.Errorf("Element 1,0 is not correct : %v", s.At(1, 0))
+			}
+		})
+	}
+
+	t.Run("Symmetric", func(t *testing.T) {
+		size := 40
+		A := laplacian(size)
+		b := mat.NewDense(size, 1, nil)
+		for i := 0; i < size; i++ {
+			b.Set(i, 0, 1.0)
+		}
+		for _, opt := range []string{"-i cg", "-i bicgstab", "-i gmres -restart 10"} {
+			s, _, _, err := golis.LsolveNative(A, b, opt)
+			if err != nil {
+				t.Fatalf("Not correct result for `%s`: %v", opt, err)
+			}
+			if r := residual(A, s, b); r > 1e-8 {
+				t.Errorf("Residual is too big for `%s`: %v", opt, r)
+			}
+		}
+	})
+}
+
+func TestLsolveNativeFail(t *testing.T) {
+	A := convectionDiffusion(100)
+	b := mat.NewDense(100, 1, nil)
+	for i := 0; i < 100; i++ {
+		b.Set(i, 0, math.Sin(float64(i)))
+	}
+
+	for i, tc := range []struct {
+		options string
+		err     error
+	}{
+		{"-i bicgstab -maxiter 2", golis.Maxiter},
+		{"-i gmres -restart 2 -maxiter 3", golis.Maxiter},
+		{"-i jacobi", golis.NotImplemented},
+		{"-p sainv", golis.NotImplemented},
+		{"-p ilu -ilu_fill 1", golis.NotImplemented},
+		{"-p ssor -ssor_omega 2", golis.IllOption},
+		{"-f quad", golis.NotImplemented},
+		{"-adds true", golis.NotImplemented},
+		{"-maxiter", golis.IllOption},
+		{"-maxiter -1", golis.IllOption},
+		{"-tol abc", golis.IllOption},
+		{"-tol inf", golis.IllOption},
+		{"-tol NaN", golis.IllOption},
+		{"-i cg", golis.IllOption},
+		{"-restart 0", golis.IllOption},
+	} {
+		t.Run(fmt.Sprintf("Fail%d", i), func(t *testing.T) {
+			_, _, _, err := golis.LsolveNative(A, b, tc.options)
+			if err != tc.err {
+				t.Fatalf("Not correct error: %v", err)
+			}
+		})
+	}
+
+	t.Run("Breakdown", func(t *testing.T) {
+		A := golis.NewSparseMatrix(2, 2)
+		A.Add(0, 1, 1.0)
+		A.Add(1, 0, 1.0)
+		b := mat.NewDense(2, 1, []float64{1.0, 0.0})
+		_, _, err := golis.BiCGSTAB(A, b, 1e-12, 100)
+		if err != golis.Breakdown {
+			t.Fatalf("Not correct error: %v", err)
+		}
+	})
+}
+
+func TestBiCGSTAB(t *testing.T) {
+	size := 50
+	A := convectionDiffusion(size)
+	b := mat.NewDense(size, 1, nil)
+	for i := 0; i < size; i++ {
+		b.Set(i, 0, float64(i))
+	}
+	s, rhistory, err := golis.BiCGSTAB(A, b, 1e-12, 1000)
+	if err != nil {
+		t.Fatalf("Not correct result: %v", err)
+	}
+	if r := residual(A, s, b); r > 1e-8 {
+		t.Errorf("Residual is too big: %v", r)
+	}
+	if rhistory[0] != 1.0 {
+		t.Errorf("Not correct residual history: %v", rhistory)
+	}
+}
+
+func TestGMRES(t *testing.T) {
+	size := 50
+	A := convectionDiffusion(size)
+	b := mat.NewDense(size, 1, nil)
+	for i := 0; i < size; i++ {
+		b.Set(i, 0, float64(i))
+	}
+	for _, restart := range []int{1, 5, 20, 100} {
+		t.Run(fmt.Sprintf("Restart%d", restart), func(t *testing.T) {
+			s, _, err := golis.GMRES(A, b, restart, 1e-12, 10000)
+			if err != nil {
+				t.Fatalf("Not correct result: %v", err)
+			}
+			if r := residual(A, s, b); r > 1e-8 {
+				t.Errorf("Residual is too big: %v", r)
+			}
+		})
+	}
+	t.Run("Restart0", func(t *testing.T) {
+		_, _, err := golis.GMRES(A, b, 0, 1e-12, 10000)
+		if err == nil {
+			t.Fatalf("Haven`t error : %v", err)
+		}
+	})
+}
+
+func TestLsolveNativeMultiple(t *testing.T) {
+	size := 20
+	A := convectionDiffusion(size)
+	B := mat.NewDense(size, 3, nil)
+	for i := 0; i < size; i++ {
+		B.Set(i, 0, 1.0)
+		B.Set(i, 1, float64(i))
+		B.Set(i, 2, float64(i%3))
+	}
+	for _, options := range []string{"-i bicgstab", "-i gmres -restart 10"} {
+		s, rhistory, output, err := golis.NativeSolver{}.SolveMultiple(A, B, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r, c := s.Dims(); r != size || c != 3 || len(rhistory) != 3 || len(output) != 3 {
+			t.Fatalf("Not valid sizes of results: [%d,%d], %d, %d",
+				r, c, len(rhistory), len(output))
+		}
+		// several columns by LsolveNative
+		x, rh, _, err := golis.LsolveNative(A, B, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !mat.Equal(x, s) || len(rh) != len(rhistory[0])+len(rhistory[1])+len(rhistory[2]) {
+			t.Fatalf("Not same results of LsolveNative")
+		}
+		for j := 0; j < 3; j++ {
+			b := mat.DenseCopyOf(B.ColView(j))
+			x, rh, _, err := golis.LsolveNative(A, b, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !mat.Equal(x, mat.DenseCopyOf(s).ColView(j)) {
+				t.Fatalf("Solution of column %d is not same", j)
+			}
+			if len(rh) != len(rhistory[j]) {
+				t.Fatalf("Residual history of column %d is not same", j)
+			}
+		}
+	}
+
+	// error in first column
+	_, rhistory, output, err := golis.LsolveNativeMultiple(A, B, "-i bicgstab -maxiter 1")
+	if err != golis.Maxiter || len(rhistory) != 1 || len(output) != 1 {
+		t.Fatalf("Not correct error: %v, %d, %d", err, len(rhistory), len(output))
+	}
+}
+
+func TestLsolveNativeInitial(t *testing.T) {
+	size := 30
+	A := convectionDiffusion(size)
+	b := mat.NewDense(size, 1, nil)
+	for i := 0; i < size; i++ {
+		b.Set(i, 0, float64(i%5))
+	}
+	for _, tc := range []struct {
+		A       mat.Matrix
+		options string
+	}{
+		{A: A, options: "-i bicgstab"},
+		{A: A, options: "-i gmres"},
+		{A: laplacian(size), options: "-i cg"},
+	} {
+		A, options := tc.A, tc.options
+		t.Run(options, func(t *testing.T) {
+			x, cold, _, err := golis.LsolveNative(A, b, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// exact initial guess
+			_, rhistory, output, err := golis.LsolveNativeInitial(A, b, x, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rhistory) != 1 {
+				t.Fatalf("Not valid warm start: %v", rhistory)
+			}
+			if !strings.Contains(output, "user defined") {
+				t.Fatalf("Not valid output:\n%s", output)
+			}
+
+			// perturbed initial guess
+			x0 := mat.DenseCopyOf(x)
+			x0.Set(size/2, 0, x0.At(size/2, 0)+1e-3)
+			s, warm, _, err := golis.NativeSolver{}.SolveInitial(A, b, x0, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if warm[0] >= 1.0 || len(warm) > len(cold) {
+				t.Fatalf("Not valid warm start: %d > %d", len(warm), len(cold))
+			}
+			if r := residual(A, s, b); r > 1e-8 {
+				t.Fatalf("Residual: %v", r)
+			}
+
+			// initial guess is ignored
+			_, rhistory, _, err = golis.LsolveNativeInitial(A, b, x, options+" -initx_zeros true")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rhistory) != len(cold) {
+				t.Fatalf("Initial guess is not ignored")
+			}
+		})
+	}
+
+	// typed nil initial guess is zero initial guess
+	if _, rhistory, _, err := golis.LsolveNativeInitial(A, b, (*mat.Dense)(nil), ""); err != nil || rhistory[0] != 1.0 {
+		t.Fatalf("Not valid nil initial guess: %v", err)
+	}
+	if _, _, _, err := golis.LsolveNativeInitial(A, b, mat.NewDense(2, 1, nil), ""); err == nil {
+		t.Fatalf("Haven`t error for not valid initial guess")
+	}
+	if _, _, _, err := golis.LsolveNative(A, b, "-initx_zeros maybe"); err != golis.IllOption {
+		t.Fatalf("Not valid error: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package golis
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Konstantin8105/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Options is typed options of `lis` solvers and preconditioners.
+// Fields with zero values are not written, so default values of
+// `lis` software are used.
+//
+// Example:
+//
+//	opts := golis.Options{Solver: "gmres", Restart: 20, Preconditioner: "ilu"}
+//	solution, rhistory, output, err := golis.LsolveOptions(A, b, opts)
+//
+// Options are validated by functions LsolveOptions and SolveOptions
+// before execution. For other functions options can be converted by
+// method String after validation.
+type Options struct {
+	Solver         string  // -i, for example: cg, bicgstab, gmres
+	Preconditioner string  // -p, for example: none, jacobi, ilu, ssor
+	Maxiter        int     // -maxiter, maximal amount of iterations
+	Tol            float64 // -tol, convergence tolerance
+	Restart        int     // -restart, restart value for GMRES, FGMRES and Orthomin
+	Precision      string  // -f, precision: double, quad
+	Threads        int     // -omp_num_threads, amount of threads
+	Storage        string  // -storage, matrix storage format, for example: csr
+	ILUFill        int     // -ilu_fill, fill level of ILU preconditioner
+	SSOROmega      float64 // -ssor_omega, relaxation of SSOR preconditioner
+}
+
+// Names of `lis` solvers, preconditioners, precisions and storage formats
+var (
+	optionSolvers = []string{
+		"cg", "bicg", "cgs", "bicgstab", "bicgstabl", "gpbicg", "tfqmr",
+		"orthomin", "gmres", "jacobi", "gs", "sor", "bicgsafe", "cr",
+		"bicr", "crs", "bicrstab", "gpbicr", "bicrsafe", "fgmres", "idrs",
+		"idr1", "minres", "cocg", "cocr",
+	}
+	optionPreconditioners = []string{
+		"none", "jacobi", "ilu", "ssor", "hybrid", "is", "sainv", "saamg",
+		"iluc", "ilut", "ic",
+	}
+	optionPrecisions = []string{"double", "quad"}
+	optionStorages   = []string{
+		"csr", "csc", "msr", "dia", "ell", "jad", "bsr", "bsc", "vbr",
+		"coo", "dns",
+	}
+)
+
+// Validate returns error, if names or values of options are not valid.
+func (o Options) Validate() error {
+	var et errors.Tree
+	et.Name = "Check options"
+	check := func(option, value string, names []string) {
+		if value == "" {
+			return
+		}
+		for _, name := range names {
+			if value == name {
+				return
+			}
+		}
+		et.Add(fmt.Errorf("Not valid value of option %s: `%s`. Valid values: %s",
+			option, value, strings.Join(names, ", ")))
+	}
+	check("-i", o.Solver, optionSolvers)
+	check("-p", o.Preconditioner, optionPreconditioners)
+	check("-f", o.Precision, optionPrecisions)
+	check("-storage", o.Storage, optionStorages)
+
+	if o.Maxiter < 0 {
+		et.Add(fmt.Errorf("Amount of iterations cannot be less zero: %d", o.Maxiter))
+	}
+	if o.Tol < 0 || math.IsNaN(o.Tol) || math.IsInf(o.Tol, 0) {
+		et.Add(fmt.Errorf("Tolerance is not valid: %v", o.Tol))
+	}
+	if o.Restart < 0 {
+		et.Add(fmt.Errorf("Restart cannot be less zero: %d", o.Restart))
+	}
+	if o.Threads < 0 {
+		et.Add(fmt.Errorf("Amount of threads cannot be less zero: %d", o.Threads))
+	}
+	if o.ILUFill < 0 {
+		et.Add(fmt.Errorf("Fill level of ILU cannot be less zero: %d", o.ILUFill))
+	}
+	if o.ILUFill != 0 && o.Preconditioner != "ilu" {
+		et.Add(fmt.Errorf("Fill level of ILU is used with preconditioner `%s`",
+			o.Preconditioner))
+	}
+	if o.SSOROmega != 0 && !(0 < o.SSOROmega && o.SSOROmega < 2) {
+		et.Add(fmt.Errorf("Relaxation of SSOR is not in range (0,2): %v", o.SSOROmega))
+	}
+	if o.SSOROmega != 0 && o.Preconditioner != "ssor" {
+		et.Add(fmt.Errorf("Relaxation of SSOR is used with preconditioner `%s`",
+			o.Preconditioner))
+	}
+	if et.IsError() {
+		return et
+	}
+	return nil
+}
+
+// LsolveOptions is same as Lsolve, but with typed options.
+// Options are validated before writing of temp files and executing
+// of `lsolve`, so not valid options return error of validation.
+func LsolveOptions(A, b mat.Matrix, opts Options) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return LisSolver{
+		Path:          LisPath,
+		TempDir:       LisTempDir,
+		KeepOnFailure: LisKeepOnFailure,
+	}.SolveOptions(A, b, opts)
+}
+
+// SolveOptions returns solution matrix of iterative solve for linear
+// system with typed options. See description of LsolveOptions.
+func (s LisSolver) SolveOptions(A, b mat.Matrix, opts Options) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	if err = opts.Validate(); err != nil {
+		return
+	}
+	return s.Solve(A, b, opts.String())
+}
+
+// Args returns list of arguments for `lis` software.
+// Options are not validated.
+func (o Options) Args() (args []string) {
+	add := func(option, value string) {
+		args = append(args, option, value)
+	}
+	if o.Solver != "" {
+		add("-i", o.Solver)
+	}
+	if o.Preconditioner != "" {
+		add("-p", o.Preconditioner)
+	}
+	if o.Maxiter != 0 {
+		add("-maxiter", strconv.Itoa(o.Maxiter))
+	}
+	if o.Tol != 0 {
+		add("-tol", strconv.FormatFloat(o.Tol, 'g', -1, 64))
+	}
+	if o.Restart != 0 {
+		add("-restart", strconv.Itoa(o.Restart))
+	}
+	if o.Precision != "" {
+		add("-f", o.Precision)
+	}
+	if o.Threads != 0 {
+		add("-omp_num_threads", strconv.Itoa(o.Threads))
+	}
+	if o.Storage != "" {
+		add("-storage", o.Storage)
+	}
+	if o.ILUFill != 0 {
+		add("-ilu_fill", strconv.Itoa(o.ILUFill))
+	}
+	if o.SSOROmega != 0 {
+		add("-ssor_omega", strconv.FormatFloat(o.SSOROmega, 'g', -1, 64))
+	}
+	return
+}
+
+// String returns options in `lis` syntax for functions Lsolve,
+// LsolveNative and others. Options are not validated.
+//
+// Example:
+//
+//	-i gmres -restart 20
+func (o Options) String() string {
+	return strings.Join(o.Args(), " ")
+}
@@ -0,0 +1,160 @@
+package golis_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestOptions(t *testing.T) {
+	for _, tc := range []struct {
+		opts   golis.Options
+		expect string
+	}{
+		{golis.Options{}, ""},
+		{golis.Options{Solver: "gmres", Restart: 20}, "-i gmres -restart 20"},
+		{golis.Options{Solver: "bicgstab", Maxiter: 20000}, "-i bicgstab -maxiter 20000"},
+		{golis.Options{Precision: "quad"}, "-f quad"},
+		{
+			golis.Options{
+				Solver:         "cg",
+				Preconditioner: "ilu",
+				Maxiter:        100,
+				Tol:            1e-10,
+				Threads:        4,
+				Storage:        "csr",
+				ILUFill:        2,
+			},
+			"-i cg -p ilu -maxiter 100 -tol 1e-10 -omp_num_threads 4 -storage csr -ilu_fill 2",
+		},
+		{golis.Options{Preconditioner: "ssor", SSOROmega: 1.5}, "-p ssor -ssor_omega 1.5"},
+	} {
+		t.Run(tc.expect, func(t *testing.T) {
+			if err := tc.opts.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if s := tc.opts.String(); s != tc.expect {
+				t.Fatalf("Not same:\n%s\n%s", s, tc.expect)
+			}
+		})
+	}
+}
+
+func TestOptionsFail(t *testing.T) {
+	for i, opts := range []golis.Options{
+		{Solver: "cgg"},
+		{Preconditioner: "ilu0"},
+		{Precision: "single"},
+		{Storage: "triple"},
+		{Maxiter: -1},
+		{Tol: -1e-10},
+		{Tol: math.NaN()},
+		{Restart: -2},
+		{Threads: -4},
+		{ILUFill: -1, Preconditioner: "ilu"},
+		{ILUFill: 1},
+		{SSOROmega: 2.5, Preconditioner: "ssor"},
+		{SSOROmega: 1.2, Preconditioner: "ilu"},
+	} {
+		t.Run(fmt.Sprintf("Fail%d", i), func(t *testing.T) {
+			err := opts.Validate()
+			if err == nil {
+				t.Fatalf("Haven`t error for options: %#v", opts)
+			}
+			t.Log(err)
+		})
+	}
+}
+
+func TestOptionsNative(t *testing.T) {
+	A := convectionDiffusion(10)
+	b := mat.NewDense(10, 1, nil)
+	b.Set(0, 0, 1.0)
+	opts := golis.Options{Solver: "gmres", Restart: 5, Tol: 1e-10}
+	_, _, output, err := golis.LsolveNative(A, b, opts.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := golis.ParseSolverReport(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Solver != "GMRES" {
+		t.Fatalf("Not valid solver: %s", r.Solver)
+	}
+}
+
+func TestLsolveArguments(t *testing.T) {
+	// fake `lsolve` is failed for empty arguments
+	lisPath := fakeLis(t, `
+for a in "$@"; do
+	[ -n "$a" ] || exit 1
+done
+`+fakeLsolveSuccess)
+	defer func() { _ = os.RemoveAll(lisPath) }()
+
+	A := mat.NewDense(2, 2, []float64{
+		1.0, 2.0,
+		4.0, 1.0,
+	})
+	b := mat.NewDense(2, 1, []float64{
+		4.0,
+		9.0,
+	})
+	solver := golis.LisSolver{Path: lisPath}
+	for _, options := range []string{"", " -i  cg ", "-i cg\t-maxiter 10"} {
+		if _, _, _, err := solver.Solve(A, b, options); err != nil {
+			t.Fatalf("Options %q: %v", options, err)
+		}
+	}
+}
+
+func TestSolveOptions(t *testing.T) {
+	// fake `lsolve` writes arguments in file
+	lisPath := fakeLis(t, `echo "$@" > "$(dirname "$0")/args"
+`+fakeLsolveSuccess)
+	defer func() { _ = os.RemoveAll(lisPath) }()
+
+	A := mat.NewDense(2, 2, []float64{
+		1.0, 2.0,
+		4.0, 1.0,
+	})
+	b := mat.NewDense(2, 1, []float64{
+		4.0,
+		9.0,
+	})
+	solver := golis.LisSolver{Path: lisPath}
+	args := filepath.Join(lisPath, "args")
+
+	// not valid options
+	_, _, _, err := solver.SolveOptions(A, b, golis.Options{Solver: "cgg"})
+	if err == nil {
+		t.Fatalf("Haven`t error for not valid options")
+	}
+	if _, err := os.Stat(args); !os.IsNotExist(err) {
+		t.Fatalf("`lsolve` is executed for not valid options: %v", err)
+	}
+
+	// valid options
+	s, _, _, err := solver.SolveOptions(A, b, golis.Options{Solver: "gmres", Restart: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(s.At(0, 0)-2) > 1e-10 || math.Abs(s.At(1, 0)-1) > 1e-10 {
+		t.Fatalf("Not valid solution:\n%v", mat.Formatted(s))
+	}
+	content, err := ioutil.ReadFile(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "-i gmres -restart 20") {
+		t.Fatalf("Not valid arguments: %s", content)
+	}
+}
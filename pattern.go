@@ -0,0 +1,216 @@
+package golis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// guarantee PatternMatrix have interface of gonum.mat.Matrix
+var _ mat.Matrix = (*PatternMatrix)(nil)
+
+// PatternError is error of access to matrix element outside of
+// sparsity pattern.
+type PatternError struct {
+	Row, Col int
+}
+
+func (e PatternError) Error() string {
+	return fmt.Sprintf("Element [%d,%d] is outside of sparsity pattern", e.Row, e.Col)
+}
+
+// PatternMatrix is sparse matrix with fixed sparsity pattern.
+// Values of elements are stored in pre-located slots, so methods
+// Add and Set do not allocate memory. Typical usage for repeated
+// assembly of matrix with same pattern:
+//
+//	p := m.Pattern() // m is assembled sparse matrix
+//	for step := 0; step < steps; step++ {
+//		p.Zero()
+//		for _, e := range elements {
+//			if err := p.Add(e.r, e.c, e.v); err != nil {
+//				...
+//			}
+//		}
+//		... solve with matrix p
+//	}
+type PatternMatrix struct {
+	r, c      int
+	symmetric bool       // only upper triangle elements
+	cs        compressed // columns
+}
+
+// Pattern returns matrix with sparsity pattern of non-zero elements
+// and copy of values.
+func (m *SparseMatrix) Pattern() *PatternMatrix {
+	return &PatternMatrix{r: m.r, c: m.c, cs: m.CSC().cs}
+}
+
+// Pattern returns symmetric matrix with sparsity pattern of non-zero
+// elements in upper triangle and copy of values.
+func (m *SparseMatrixSymmetric) Pattern() *PatternMatrix {
+	return &PatternMatrix{r: m.s.r, c: m.s.c, symmetric: true, cs: m.s.CSC().cs}
+}
+
+// index returns index of slot for element [r,c].
+// If element is outside of pattern, then return -1.
+func (m *PatternMatrix) index(r, c int) int {
+	if m.symmetric && r > c {
+		r, c = c, r
+	}
+	ind := m.cs.ind[m.cs.ptr[c]:m.cs.ptr[c+1]]
+	index := sort.SearchInts(ind, r)
+	if index < len(ind) && ind[index] == r {
+		return m.cs.ptr[c] + index
+	}
+	return -1
+}
+
+// slot returns index of slot for changing element [r,c].
+// If r,c outside of matrix or element is lower triangle element
+// of symmetric matrix, then create panic.
+func (m *PatternMatrix) slot(r, c int) (int, error) {
+	checkIndexes(r, c, m.r, m.c)
+	if m.symmetric && r > c {
+		panic(fmt.Errorf("PatternMatrix is symmetric and have only upper value: %d <= %d", r, c))
+	}
+	index := m.index(r, c)
+	if index < 0 {
+		return -1, PatternError{Row: r, Col: c}
+	}
+	return index, nil
+}
+
+// At returns the value of a matrix element at row i, column j.
+// It will panic if i or j are out of bounds for the matrix.
+func (m *PatternMatrix) At(r, c int) float64 {
+	checkIndexes(r, c, m.r, m.c)
+	if index := m.index(r, c); index >= 0 {
+		return m.cs.data[index]
+	}
+	return 0.0
+}
+
+// Dims returns the dimensions of a Matrix.
+// Where: r - amount of rows, c - amount of columns.
+func (m *PatternMatrix) Dims() (r, c int) {
+	return m.r, m.c
+}
+
+// T returns the transpose of the Matrix. Symmetric matrix returns itself.
+func (m *PatternMatrix) T() mat.Matrix {
+	if m.symmetric {
+		return m
+	}
+	return mat.Transpose{Matrix: m}
+}
+
+// IsSymmetric returns true for symmetric pattern.
+func (m *PatternMatrix) IsSymmetric() bool {
+	return m.symmetric
+}
+
+// NNZ returns amount of slots in sparsity pattern.
+func (m *PatternMatrix) NNZ() int {
+	return len(m.cs.ind)
+}
+
+// Set set value in slot of matrix element [r,c].
+// If element is outside of sparsity pattern, then return PatternError.
+// If r,c outside of matrix or value is not valid, then create panic.
+func (m *PatternMatrix) Set(r, c int, value float64) error {
+	checkValue(value)
+	index, err := m.slot(r, c)
+	if err != nil {
+		return err
+	}
+	m.cs.data[index] = value
+	return nil
+}
+
+// Add is addition value to slot of matrix element [r,c].
+// If element is outside of sparsity pattern, then return PatternError.
+// Addition of zero value is always valid.
+// If r,c outside of matrix or value is not valid, then create panic.
+func (m *PatternMatrix) Add(r, c int, value float64) error {
+	checkValue(value)
+	if math.Abs(value) == 0.0 { // no need addition zero value
+		checkIndexes(r, c, m.r, m.c)
+		return nil
+	}
+	index, err := m.slot(r, c)
+	if err != nil {
+		return err
+	}
+	m.cs.data[index] += value
+	return nil
+}
+
+// AddBlock is addition of dense element matrix ke to matrix by
+// global indexes of element rows and columns. See SparseMatrix.AddBlock.
+// If any non-zero element is outside of sparsity pattern, then return
+// PatternError and matrix is not changed.
+func (m *PatternMatrix) AddBlock(indices []int, ke mat.Matrix) error {
+	ts := blockTriples(nil, m.r, m.c, m.symmetric, indices, ke)
+	slots := make([]int, len(ts))
+	for i := range ts {
+		r := int(ts[i].position % int64(m.r))
+		c := int(ts[i].position / int64(m.r))
+		if slots[i] = m.index(r, c); slots[i] < 0 {
+			return PatternError{Row: r, Col: c}
+		}
+	}
+	for i := range ts {
+		m.cs.data[slots[i]] += ts[i].d
+	}
+	return nil
+}
+
+// Zero set zero values for all slots of sparsity pattern.
+// Pattern is not changed.
+func (m *PatternMatrix) Zero() {
+	for i := range m.cs.data {
+		m.cs.data[i] = 0.0
+	}
+}
+
+// Sparse returns sparse matrix with non-zero values of matrix.
+// For symmetric pattern returns *SparseMatrixSymmetric, otherwise
+// returns *SparseMatrix.
+func (m *PatternMatrix) Sparse() mat.Matrix {
+	out := NewSparseMatrix(m.r, m.c)
+	out.data.ts = make([]triple, 0, len(m.cs.ind))
+	for c := 0; c < m.c; c++ {
+		for k := m.cs.ptr[c]; k < m.cs.ptr[c+1]; k++ {
+			if math.Abs(m.cs.data[k]) == 0.0 {
+				continue
+			}
+			out.data.ts = append(out.data.ts, triple{
+				position: int64(m.cs.ind[k]) + int64(c)*int64(m.r),
+				d:        m.cs.data[k],
+			})
+		}
+	}
+	if m.symmetric {
+		return &SparseMatrixSymmetric{s: out}
+	}
+	return out
+}
+
+// mulVec calculate vector y = m * x
+func (m *PatternMatrix) mulVec(y, x []float64) {
+	for i := range y {
+		y[i] = 0.0
+	}
+	for c := 0; c < m.c; c++ {
+		for k := m.cs.ptr[c]; k < m.cs.ptr[c+1]; k++ {
+			r := m.cs.ind[k]
+			y[r] += m.cs.data[k] * x[c]
+			if m.symmetric && r != c {
+				y[c] += m.cs.data[k] * x[r]
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package golis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestPatternMatrix(t *testing.T) {
+	amount := 20
+	size := 2*amount + 2
+
+	// assembly of matrix with factor for values
+	assembly := func(factor float64, add func(indices []int, ke mat.Matrix)) {
+		for e := 0; e < amount; e++ {
+			dofs, k := element(e)
+			ke := mat.NewDense(4, 4, nil)
+			for i := range dofs {
+				for j := range dofs {
+					ke.Set(i, j, factor*k[i][j])
+				}
+			}
+			add(dofs, ke)
+		}
+	}
+
+	m := golis.NewSparseMatrix(size, size)
+	assembly(1, m.AddBlock)
+	s := golis.NewSparseMatrixSymmetric(size)
+	assembly(1, s.AddBlock)
+
+	for _, tc := range []struct {
+		name    string
+		pattern *golis.PatternMatrix
+	}{
+		{name: "General", pattern: m.Pattern()},
+		{name: "Symmetric", pattern: s.Pattern()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tc.pattern
+			if p.IsSymmetric() != (tc.name == "Symmetric") {
+				t.Fatalf("Not valid symmetry of pattern")
+			}
+			if !mat.Equal(p, m) {
+				t.Fatalf("Not same values of pattern")
+			}
+			for step := 1; step <= 3; step++ {
+				p.Zero()
+				if p.NNZ() == 0 || p.At(0, 0) != 0.0 {
+					t.Fatalf("Not valid zero of pattern")
+				}
+				assembly(float64(step), func(indices []int, ke mat.Matrix) {
+					if err := p.AddBlock(indices, ke); err != nil {
+						t.Fatal(err)
+					}
+				})
+				var expect mat.Dense
+				expect.Scale(float64(step), m)
+				if !mat.EqualApprox(p, &expect, 1e-12) {
+					t.Fatalf("Not same values of pattern on step %d", step)
+				}
+				if !mat.EqualApprox(p.Sparse(), &expect, 1e-12) {
+					t.Fatalf("Not same values of sparse matrix on step %d", step)
+				}
+			}
+
+			// values-only mode without allocations
+			allocs := testing.AllocsPerRun(10, func() {
+				if err := p.Add(1, 2, 1.0); err != nil {
+					t.Fatal(err)
+				}
+				if err := p.Set(0, 0, 5.0); err != nil {
+					t.Fatal(err)
+				}
+			})
+			if allocs != 0 {
+				t.Fatalf("Allocations: %v", allocs)
+			}
+			if v := p.At(0, 0); v != 5.0 {
+				t.Fatalf("Not valid value: %v", v)
+			}
+
+			// element outside of pattern
+			err := p.Add(0, size-1, 1.0)
+			if pe, ok := err.(golis.PatternError); !ok || pe.Row != 0 || pe.Col != size-1 {
+				t.Fatalf("Not valid error: %v", err)
+			}
+			t.Log(err)
+			if err := p.Set(0, size-1, 1.0); err == nil {
+				t.Fatalf("Haven`t error for element outside of pattern")
+			}
+			if err := p.Add(0, size-1, 0.0); err != nil {
+				t.Fatalf("Addition of zero value: %v", err)
+			}
+			before := mat.DenseCopyOf(p)
+			ke := mat.NewDense(2, 2, []float64{1, 1, 1, 1})
+			if err := p.AddBlock([]int{1, size - 1}, ke); err == nil {
+				t.Fatalf("Haven`t error for element outside of pattern")
+			}
+			if !mat.Equal(p, before) {
+				t.Fatalf("Pattern is changed after error")
+			}
+		})
+	}
+}
+
+func TestPatternMatrixSolve(t *testing.T) {
+	A := laplacian(20)
+	p := A.Pattern()
+	b := mat.NewDense(20, 1, nil)
+	for i := 0; i < 20; i++ {
+		b.Set(i, 0, float64(i+1))
+	}
+	for _, options := range []string{"-i cg", "-i bicgstab"} {
+		x, _, _, err := golis.LsolveNative(p, b, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := residual(A, x, b); r > 1e-8 {
+			t.Fatalf("%s: residual %v", options, r)
+		}
+	}
+}
+
+func TestPatternMatrixPanics(t *testing.T) {
+	p := golis.NewSparseMatrixSymmetric(3).Pattern()
+	for i, f := range []func(){
+		func() { _ = p.Add(3, 0, 1.0) },
+		func() { _ = p.Set(2, 0, 1.0) },
+		func() { _ = p.Add(0, 0, 1.0/p.At(0, 0)) },
+	} {
+		t.Run(fmt.Sprintf("Panic%d", i), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				t.Logf("\n%v", r)
+				if r == nil {
+					t.Fatal("Haven`t panic for not valid data")
+				}
+			}()
+			f()
+		})
+	}
+}
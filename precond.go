@@ -0,0 +1,349 @@
+package golis
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Preconditioner is approximation M of matrix A for in-process
+// iterative solvers. Preconditioner is created once and can be
+// reused for several solves with same matrix.
+type Preconditioner interface {
+	// Precondition calculate vector z = M⁻¹ * r
+	Precondition(z, r []float64)
+
+	// Size returns amount of rows of matrix M
+	Size() int
+}
+
+// guarantee preconditioners have interface of Preconditioner
+var (
+	_ Preconditioner = (*Jacobi)(nil)
+	_ Preconditioner = (*SSOR)(nil)
+	_ Preconditioner = (*ILU0)(nil)
+	_ Preconditioner = (*IC0)(nil)
+)
+
+// precondition calculate z = M⁻¹ * r. If M is nil, then z = r.
+func precondition(M Preconditioner, z, r []float64) {
+	if M == nil {
+		copy(z, r)
+		return
+	}
+	M.Precondition(z, r)
+}
+
+// csrMatrix returns square matrix A with all elements in CSR format
+func csrMatrix(A mat.Matrix) (*CSR, error) {
+	if r, c := A.Dims(); r != c {
+		return nil, fmt.Errorf("Matrix A is not square: [%d,%d]", r, c)
+	}
+	switch v := A.(type) {
+	case *CSR:
+		return v, nil
+	case *SparseMatrix:
+		return v.CSR(), nil
+	case *SparseMatrixSymmetric:
+		return v.CSR(), nil
+	case *PatternMatrix:
+		return csrMatrix(v.Sparse())
+	}
+	return convertToSparse(A).CSR(), nil
+}
+
+// diagonal returns diagonal of matrix in CSR format.
+// If diagonal element is zero, then error PivotError is returned.
+func diagonal(a *CSR) ([]float64, error) {
+	d := make([]float64, a.r)
+	for i := range d {
+		d[i] = a.cs.at(i, i)
+		if d[i] == 0.0 {
+			return nil, PivotError{Row: i, Value: d[i]}
+		}
+	}
+	return d, nil
+}
+
+// Jacobi is diagonal preconditioner
+//
+//	M = D
+//
+// Where: D is diagonal of matrix A.
+type Jacobi struct {
+	inv []float64 // inverse values of diagonal
+}
+
+// NewJacobi returns Jacobi preconditioner of matrix A.
+// If diagonal element is zero, then error PivotError is returned.
+func NewJacobi(A mat.Matrix) (*Jacobi, error) {
+	a, err := csrMatrix(A)
+	if err != nil {
+		return nil, err
+	}
+	d, err := diagonal(a)
+	if err != nil {
+		return nil, err
+	}
+	for i := range d {
+		d[i] = 1.0 / d[i]
+	}
+	return &Jacobi{inv: d}, nil
+}
+
+// Precondition calculate vector z = M⁻¹ * r
+func (p *Jacobi) Precondition(z, r []float64) {
+	for i := range z {
+		z[i] = p.inv[i] * r[i]
+	}
+}
+
+// Size returns amount of rows of matrix M
+func (p *Jacobi) Size() int {
+	return len(p.inv)
+}
+
+// SSOR is Symmetric Successive Over-Relaxation preconditioner
+//
+//	M = (D + ωL) * D⁻¹ * (D + ωU) / (ω * (2 - ω))
+//
+// Where: A = L + D + U, L is strictly lower triangle, D is diagonal,
+// U is strictly upper triangle of matrix A, ω is relaxation.
+type SSOR struct {
+	a     *CSR
+	d     []float64 // diagonal
+	omega float64
+}
+
+// NewSSOR returns SSOR preconditioner of matrix A with relaxation
+// omega in range (0,2). If diagonal element is zero, then error
+// PivotError is returned.
+func NewSSOR(A mat.Matrix, omega float64) (*SSOR, error) {
+	if !(0 < omega && omega < 2) {
+		return nil, fmt.Errorf("Relaxation of SSOR is not in range (0,2): %v", omega)
+	}
+	a, err := csrMatrix(A)
+	if err != nil {
+		return nil, err
+	}
+	d, err := diagonal(a)
+	if err != nil {
+		return nil, err
+	}
+	return &SSOR{a: a, d: d, omega: omega}, nil
+}
+
+// Precondition calculate vector z = M⁻¹ * r
+func (p *SSOR) Precondition(z, r []float64) {
+	w := p.omega
+	// forward: (D + ωL) * y = ω(2-ω) * r
+	for i := range z {
+		s := w * (2 - w) * r[i]
+		ind, data := p.a.cs.vector(i)
+		for k, j := range ind {
+			if j >= i {
+				break
+			}
+			s -= w * data[k] * z[j]
+		}
+		z[i] = s / p.d[i]
+	}
+	// backward: (D + ωU) * z = D * y
+	for i := len(z) - 1; i >= 0; i-- {
+		s := p.d[i] * z[i]
+		ind, data := p.a.cs.vector(i)
+		for k := len(ind) - 1; k >= 0 && ind[k] > i; k-- {
+			s -= w * data[k] * z[ind[k]]
+		}
+		z[i] = s / p.d[i]
+	}
+}
+
+// Size returns amount of rows of matrix M
+func (p *SSOR) Size() int {
+	return len(p.d)
+}
+
+// ILU0 is incomplete LU factorization preconditioner without fill-in
+//
+//	M = L * U
+//
+// Where: L is lower triangle matrix with unit diagonal, U is upper
+// triangle matrix. Factors have same sparsity pattern as matrix A.
+type ILU0 struct {
+	lu   compressed // rows of L without diagonal and rows of U
+	diag []int      // indexes of diagonal elements in rows
+}
+
+// NewILU0 returns ILU(0) preconditioner of matrix A.
+// If zero pivot is found, then error PivotError is returned.
+func NewILU0(A mat.Matrix) (*ILU0, error) {
+	a, err := csrMatrix(A)
+	if err != nil {
+		return nil, err
+	}
+	n := a.r
+	p := &ILU0{
+		lu: compressed{
+			ptr:  a.cs.ptr,
+			ind:  a.cs.ind,
+			data: make([]float64, len(a.cs.data)),
+		},
+		diag: make([]int, n),
+	}
+	copy(p.lu.data, a.cs.data)
+
+	// position of element in row i
+	pos := make([]int, n)
+	for i := range pos {
+		pos[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		from, to := p.lu.ptr[i], p.lu.ptr[i+1]
+		for k := from; k < to; k++ {
+			pos[p.lu.ind[k]] = k
+		}
+		p.diag[i] = pos[i]
+		for k := from; k < to && p.lu.ind[k] < i; k++ {
+			j := p.lu.ind[k]
+			// L[i,j] = A[i,j] / U[j,j]
+			p.lu.data[k] /= p.lu.data[p.diag[j]]
+			for kj := p.diag[j] + 1; kj < p.lu.ptr[j+1]; kj++ {
+				if ik := pos[p.lu.ind[kj]]; ik >= 0 {
+					p.lu.data[ik] -= p.lu.data[k] * p.lu.data[kj]
+				}
+			}
+		}
+		if p.diag[i] < 0 || p.lu.data[p.diag[i]] == 0.0 {
+			return nil, PivotError{Row: i}
+		}
+		for k := from; k < to; k++ {
+			pos[p.lu.ind[k]] = -1
+		}
+	}
+	return p, nil
+}
+
+// Precondition calculate vector z = M⁻¹ * r
+func (p *ILU0) Precondition(z, r []float64) {
+	// forward: L * y = r
+	for i := range z {
+		s := r[i]
+		for k := p.lu.ptr[i]; k < p.diag[i]; k++ {
+			s -= p.lu.data[k] * z[p.lu.ind[k]]
+		}
+		z[i] = s
+	}
+	// backward: U * z = y
+	for i := len(z) - 1; i >= 0; i-- {
+		s := z[i]
+		for k := p.diag[i] + 1; k < p.lu.ptr[i+1]; k++ {
+			s -= p.lu.data[k] * z[p.lu.ind[k]]
+		}
+		z[i] = s / p.lu.data[p.diag[i]]
+	}
+}
+
+// Size returns amount of rows of matrix M
+func (p *ILU0) Size() int {
+	return len(p.diag)
+}
+
+// IC0 is incomplete Cholesky factorization preconditioner without
+// fill-in for symmetric positive-definite matrix
+//
+//	M = L * Lᵀ
+//
+// Where: L is lower triangle matrix with same sparsity pattern as
+// lower triangle of matrix A.
+type IC0 struct {
+	l compressed // rows of L, diagonal is last element in row
+}
+
+// NewIC0 returns IC(0) preconditioner of symmetric matrix A.
+// Only lower triangle of matrix A is used.
+// If pivot is not positive, then error PivotError is returned.
+func NewIC0(A mat.Matrix) (*IC0, error) {
+	a, err := csrMatrix(A)
+	if err != nil {
+		return nil, err
+	}
+	n := a.r
+
+	// lower triangle of matrix
+	p := &IC0{l: compressed{ptr: make([]int, n+1)}}
+	for i := 0; i < n; i++ {
+		ind, data := a.cs.vector(i)
+		for k, j := range ind {
+			if j > i {
+				break
+			}
+			p.l.ind = append(p.l.ind, j)
+			p.l.data = append(p.l.data, data[k])
+		}
+		p.l.ptr[i+1] = len(p.l.ind)
+	}
+
+	for i := 0; i < n; i++ {
+		from, to := p.l.ptr[i], p.l.ptr[i+1]
+		if from == to || p.l.ind[to-1] != i {
+			return nil, PivotError{Row: i}
+		}
+		for k := from; k < to; k++ {
+			j := p.l.ind[k]
+			// sparse dot product of rows i and j for columns less j
+			s := p.l.data[k]
+			ki, kj := from, p.l.ptr[j]
+			for ki < k && p.l.ind[kj] < j {
+				switch {
+				case p.l.ind[ki] < p.l.ind[kj]:
+					ki++
+				case p.l.ind[ki] > p.l.ind[kj]:
+					kj++
+				default:
+					s -= p.l.data[ki] * p.l.data[kj]
+					ki++
+					kj++
+				}
+			}
+			if j < i {
+				// L[i,j] = (A[i,j] - sum(L[i,k]*L[j,k])) / L[j,j]
+				p.l.data[k] = s / p.l.data[p.l.ptr[j+1]-1]
+				continue
+			}
+			// diagonal
+			if !(s > 0) {
+				return nil, PivotError{Row: i, Value: s}
+			}
+			p.l.data[k] = math.Sqrt(s)
+		}
+	}
+	return p, nil
+}
+
+// Precondition calculate vector z = M⁻¹ * r
+func (p *IC0) Precondition(z, r []float64) {
+	// forward: L * y = r
+	for i := range z {
+		s := r[i]
+		last := p.l.ptr[i+1] - 1
+		for k := p.l.ptr[i]; k < last; k++ {
+			s -= p.l.data[k] * z[p.l.ind[k]]
+		}
+		z[i] = s / p.l.data[last]
+	}
+	// backward: Lᵀ * z = y
+	for i := len(z) - 1; i >= 0; i-- {
+		last := p.l.ptr[i+1] - 1
+		z[i] /= p.l.data[last]
+		for k := p.l.ptr[i]; k < last; k++ {
+			z[p.l.ind[k]] -= p.l.data[k] * z[i]
+		}
+	}
+}
+
+// Size returns amount of rows of matrix M
+func (p *IC0) Size() int {
+	return len(p.l.ptr) - 1
+}
@@ -0,0 +1,211 @@
+package golis_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+// laplacian2d returns symmetric matrix of 2D Poisson problem
+// on grid size x size
+func laplacian2d(size int) *golis.SparseMatrixSymmetric {
+	A := golis.NewSparseMatrixSymmetric(size * size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			k := i*size + j
+			A.Add(k, k, 4.0)
+			if j+1 < size {
+				A.Add(k, k+1, -1.0)
+			}
+			if i+1 < size {
+				A.Add(k, k+size, -1.0)
+			}
+		}
+	}
+	return A
+}
+
+func TestPreconditioner(t *testing.T) {
+	size := 12
+	A := laplacian2d(size)
+	n, _ := A.Dims()
+	b := mat.NewDense(n, 1, nil)
+	for i := 0; i < n; i++ {
+		b.Set(i, 0, float64(i%7)-3.0)
+	}
+
+	for _, solver := range []string{"cg", "bicgstab", "gmres -restart 10"} {
+		_, none, _, err := golis.LsolveNative(A, b, "-i "+solver)
+		if err != nil {
+			t.Fatalf("Not correct result for `%s`: %v", solver, err)
+		}
+		for _, p := range []string{"jacobi", "ssor", "ssor -ssor_omega 1.5", "ilu", "ic"} {
+			options := fmt.Sprintf("-i %s -p %s", solver, p)
+			t.Run(options, func(t *testing.T) {
+				s, rhistory, output, err := golis.LsolveNative(A, b, options)
+				if err != nil {
+					t.Fatalf("Not correct result: %v", err)
+				}
+				if r := residual(A, s, b); r > 1e-8 {
+					t.Errorf("Residual is too big: %v", r)
+				}
+				if p != "jacobi" && len(rhistory) >= len(none) {
+					t.Errorf("Amount of iterations is not reduced: %d >= %d",
+						len(rhistory), len(none))
+				}
+				report, err := golis.ParseSolverReport(output)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if report.Preconditioner == "none" {
+					t.Errorf("Not valid preconditioner in output:\n%s", output)
+				}
+			})
+		}
+	}
+}
+
+func TestPreconditionerExact(t *testing.T) {
+	// incomplete factorizations of tridiagonal matrix are exact
+	size := 20
+	b := mat.NewDense(size, 1, nil)
+	for i := 0; i < size; i++ {
+		b.Set(i, 0, 1.0)
+	}
+	ic, err := golis.NewIC0(laplacian(size))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ilu, err := golis.NewILU0(convectionDiffusion(size))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		A mat.Matrix
+		M golis.Preconditioner
+	}{
+		{laplacian(size), ic},
+		{convectionDiffusion(size), ilu},
+	} {
+		solver := golis.NativeSolver{Preconditioner: tc.M}
+		for _, options := range []string{"-i cg", "-i bicgstab", "-i gmres"} {
+			if _, ok := tc.M.(*golis.ILU0); ok && options == "-i cg" {
+				continue
+			}
+			s, rhistory, output, err := solver.Solve(tc.A, b, options)
+			if err != nil {
+				t.Fatalf("Not correct result for `%s`: %v", options, err)
+			}
+			if len(rhistory) != 2 {
+				t.Errorf("Not exact preconditioner for `%s`: %v", options, rhistory)
+			}
+			if r := residual(tc.A, s, b); r > 1e-10 {
+				t.Errorf("Residual is too big for `%s`: %v", options, r)
+			}
+			if !strings.Contains(output, "preconditioner        : user defined") {
+				t.Errorf("Not valid output:\n%s", output)
+			}
+		}
+	}
+}
+
+func TestPreconditionerReuse(t *testing.T) {
+	A := laplacian2d(8)
+	n, _ := A.Dims()
+	M, err := golis.NewIC0(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	B := mat.NewDense(n, 3, nil)
+	for i := 0; i < n; i++ {
+		B.Set(i, 0, 1.0)
+		B.Set(i, 1, float64(i))
+		B.Set(i, 2, float64(n-i))
+	}
+	solver := golis.NativeSolver{Preconditioner: M}
+	X, _, _, err := solver.SolveMultiple(A, B, "-i cg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := residual(A, X, B); r > 1e-8 {
+		t.Errorf("Residual is too big: %v", r)
+	}
+	for j := 0; j < 3; j++ {
+		b := mat.NewDense(n, 1, mat.Col(nil, j, B))
+		x, _, _, err := solver.Solve(A, b, "-i gmres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := residual(A, x, b); r > 1e-8 {
+			t.Errorf("Residual is too big for column %d: %v", j, r)
+		}
+	}
+}
+
+func TestPreconditionerFail(t *testing.T) {
+	A := golis.NewSparseMatrix(3, 3)
+	A.Add(0, 0, 1.0)
+	A.Add(0, 1, 2.0)
+	A.Add(1, 0, 2.0)
+	A.Add(2, 2, 1.0)
+
+	for name, f := range map[string]func() error{
+		"Jacobi": func() error { _, err := golis.NewJacobi(A); return err },
+		"SSOR":   func() error { _, err := golis.NewSSOR(A, 1.0); return err },
+		"ILU0":   func() error { _, err := golis.NewILU0(A); return err },
+		"IC0":    func() error { _, err := golis.NewIC0(A); return err },
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := f()
+			if _, ok := err.(golis.PivotError); !ok {
+				t.Fatalf("Not correct error: %v", err)
+			}
+		})
+	}
+
+	t.Run("Omega", func(t *testing.T) {
+		for _, omega := range []float64{0, 2, -1} {
+			if _, err := golis.NewSSOR(laplacian(3), omega); err == nil {
+				t.Errorf("Error is not found for omega %v", omega)
+			}
+		}
+	})
+	t.Run("NotSquare", func(t *testing.T) {
+		if _, err := golis.NewJacobi(golis.NewSparseMatrix(2, 3)); err == nil {
+			t.Errorf("Error is not found")
+		}
+	})
+	t.Run("Lsolve", func(t *testing.T) {
+		b := mat.NewDense(3, 1, []float64{1, 1, 1})
+		_, _, _, err := golis.LsolveNative(A, b, "-p jacobi")
+		if _, ok := err.(golis.PivotError); !ok {
+			t.Fatalf("Not correct error: %v", err)
+		}
+	})
+	t.Run("UserDefined", func(t *testing.T) {
+		M, err := golis.NewIC0(laplacian(4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		solver := golis.NativeSolver{Preconditioner: M}
+		for _, tc := range []struct {
+			size    int
+			options string
+		}{
+			{3, "-i cg"},
+			{5, "-i gmres"},
+			{4, "-i cg -p none"},
+			{4, "-i cg -p jacobi"},
+		} {
+			b := mat.NewDense(tc.size, 1, nil)
+			_, _, _, err := solver.Solve(laplacian(tc.size), b, tc.options)
+			if err != golis.IllOption {
+				t.Errorf("Not correct error for size %d and `%s`: %v",
+					tc.size, tc.options, err)
+			}
+		}
+	})
+}
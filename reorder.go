@@ -0,0 +1,311 @@
+package golis
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Permutation is permutation of matrix indexes.
+// New index i is correspond to old index p[i].
+type Permutation []int
+
+// Inverse returns inverse permutation.
+// Old index i is correspond to new index of inverse permutation.
+func (p Permutation) Inverse() Permutation {
+	inv := make(Permutation, len(p))
+	for i := range p {
+		inv[p[i]] = i
+	}
+	return inv
+}
+
+// Permute returns matrix with rows in new order
+//
+//	result[i] = b[p[i]]
+//
+// For example, right-hand vector of linear system with reordered matrix.
+func (p Permutation) Permute(b mat.Matrix) *mat.Dense {
+	p.check(b)
+	r, c := b.Dims()
+	out := mat.NewDense(r, c, nil)
+	for i := range p {
+		for j := 0; j < c; j++ {
+			out.Set(i, j, b.At(p[i], j))
+		}
+	}
+	return out
+}
+
+// Restore returns matrix with rows in original order
+//
+//	result[p[i]] = x[i]
+//
+// For example, solution of linear system with reordered matrix.
+func (p Permutation) Restore(x mat.Matrix) *mat.Dense {
+	p.check(x)
+	r, c := x.Dims()
+	out := mat.NewDense(r, c, nil)
+	for i := range p {
+		for j := 0; j < c; j++ {
+			out.Set(p[i], j, x.At(i, j))
+		}
+	}
+	return out
+}
+
+func (p Permutation) check(m mat.Matrix) {
+	if r, _ := m.Dims(); r != len(p) {
+		panic(fmt.Errorf("Size of permutation is not same: %d != %d", len(p), r))
+	}
+}
+
+// RCM returns permutation of Reverse Cuthill-McKee algorithm
+// for reducing bandwidth and profile of matrix.
+// Pattern of non-symmetric matrix is symmetrized as pattern of A + Aᵀ.
+// Matrix must be square.
+func (m *SparseMatrix) RCM() Permutation {
+	m.checkSquare()
+	return rcm(m.adjacency())
+}
+
+// RCM returns permutation of Reverse Cuthill-McKee algorithm
+// for reducing bandwidth and profile of matrix.
+func (m *SparseMatrixSymmetric) RCM() Permutation {
+	return rcm(m.s.adjacency())
+}
+
+// Permute returns reordered matrix
+//
+//	result[i,j] = m[p[i],p[j]]
+func (m *SparseMatrix) Permute(p Permutation) *SparseMatrix {
+	m.checkSquare()
+	if len(p) != m.r {
+		panic(fmt.Errorf("Size of permutation is not same: %d != %d", len(p), m.r))
+	}
+	m.compress()
+	inv := p.Inverse()
+	out := NewSparseMatrix(m.r, m.c)
+	out.data.ts = make([]triple, len(m.data.ts))
+	for i := range m.data.ts {
+		r := inv[int(m.data.ts[i].position%int64(m.r))]
+		c := inv[int(m.data.ts[i].position/int64(m.r))]
+		out.data.ts[i] = triple{
+			position: int64(r) + int64(c)*int64(m.r),
+			d:        m.data.ts[i].d,
+		}
+	}
+	out.data.amountAdded = -1
+	out.compress()
+	return out
+}
+
+// Permute returns reordered matrix
+//
+//	result[i,j] = m[p[i],p[j]]
+func (m *SparseMatrixSymmetric) Permute(p Permutation) *SparseMatrixSymmetric {
+	if len(p) != m.s.r {
+		panic(fmt.Errorf("Size of permutation is not same: %d != %d", len(p), m.s.r))
+	}
+	m.s.compress()
+	inv := p.Inverse()
+	out := NewSparseMatrixSymmetric(m.s.r)
+	out.s.data.ts = make([]triple, len(m.s.data.ts))
+	for i := range m.s.data.ts {
+		r := inv[int(m.s.data.ts[i].position%int64(m.s.r))]
+		c := inv[int(m.s.data.ts[i].position/int64(m.s.r))]
+		if r > c {
+			// only upper triangle
+			r, c = c, r
+		}
+		out.s.data.ts[i] = triple{
+			position: int64(r) + int64(c)*int64(m.s.r),
+			d:        m.s.data.ts[i].d,
+		}
+	}
+	out.s.data.amountAdded = -1
+	out.s.compress()
+	return out
+}
+
+// Bandwidth returns maximal distance between non-zero element
+// and diagonal.
+func (m *SparseMatrix) Bandwidth() int {
+	m.compress()
+	var b int
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		if r-c > b {
+			b = r - c
+		}
+		if c-r > b {
+			b = c - r
+		}
+	}
+	return b
+}
+
+// Bandwidth returns maximal distance between non-zero element
+// and diagonal.
+func (m *SparseMatrixSymmetric) Bandwidth() int {
+	return m.s.Bandwidth()
+}
+
+// Profile returns amount of elements in skyline of matrix with
+// diagonal. Pattern of non-symmetric matrix is symmetrized as pattern
+// of A + Aᵀ. Matrix must be square.
+// See SkylineSymmetricMatrix.
+func (m *SparseMatrix) Profile() int {
+	m.checkSquare()
+	m.compress()
+	// first non-zero row in columns
+	first := make([]int, m.r)
+	for i := range first {
+		first[i] = i
+	}
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		if r > c {
+			r, c = c, r
+		}
+		if r < first[c] {
+			first[c] = r
+		}
+	}
+	var p int
+	for c := range first {
+		p += c - first[c] + 1
+	}
+	return p
+}
+
+// Profile returns amount of elements in skyline of matrix with
+// diagonal. See SkylineSymmetricMatrix.
+func (m *SparseMatrixSymmetric) Profile() int {
+	return m.s.Profile()
+}
+
+func (m *SparseMatrix) checkSquare() {
+	if m.r != m.c {
+		panic(fmt.Errorf("Matrix is not square: [%d,%d]", m.r, m.c))
+	}
+}
+
+// adjacency returns sorted lists of adjacent indexes for symmetrized
+// pattern of square matrix without diagonal
+func (m *SparseMatrix) adjacency() [][]int {
+	m.compress()
+	adj := make([][]int, m.r)
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		if r == c {
+			continue
+		}
+		adj[r] = append(adj[r], c)
+		adj[c] = append(adj[c], r)
+	}
+	// remove duplicates
+	for i := range adj {
+		sort.Ints(adj[i])
+		u := 0
+		for j := range adj[i] {
+			if j > 0 && adj[i][j] == adj[i][u-1] {
+				continue
+			}
+			adj[i][u] = adj[i][j]
+			u++
+		}
+		adj[i] = adj[i][:u]
+	}
+	return adj
+}
+
+// rcm returns permutation of Reverse Cuthill-McKee algorithm
+// by adjacency lists of graph
+func rcm(adj [][]int) Permutation {
+	n := len(adj)
+	order := make(Permutation, 0, n)
+	visited := make([]bool, n)
+
+	for len(order) < n {
+		// node with minimal degree in not visited nodes
+		start := -1
+		for i := range adj {
+			if visited[i] {
+				continue
+			}
+			if start < 0 || len(adj[i]) < len(adj[start]) {
+				start = i
+			}
+		}
+		start = peripheral(adj, start)
+
+		// breadth-first search with neighbors in order of degree
+		visited[start] = true
+		order = append(order, start)
+		for pos := len(order) - 1; pos < len(order); pos++ {
+			from := len(order)
+			for _, v := range adj[order[pos]] {
+				if !visited[v] {
+					visited[v] = true
+					order = append(order, v)
+				}
+			}
+			next := order[from:]
+			sort.SliceStable(next, func(i, j int) bool {
+				return len(adj[next[i]]) < len(adj[next[j]])
+			})
+		}
+	}
+
+	// reverse
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+// peripheral returns pseudo-peripheral node of graph component
+// by algorithm of George and Liu
+func peripheral(adj [][]int, start int) int {
+	levels := func(root int) (last []int, depth int) {
+		level := map[int]int{root: 0}
+		queue := []int{root}
+		for pos := 0; pos < len(queue); pos++ {
+			u := queue[pos]
+			for _, v := range adj[u] {
+				if _, ok := level[v]; !ok {
+					level[v] = level[u] + 1
+					queue = append(queue, v)
+				}
+			}
+		}
+		depth = level[queue[len(queue)-1]]
+		for _, u := range queue {
+			if level[u] == depth {
+				last = append(last, u)
+			}
+		}
+		return
+	}
+
+	last, depth := levels(start)
+	for {
+		// node with minimal degree in last level
+		next := last[0]
+		for _, u := range last {
+			if len(adj[u]) < len(adj[next]) {
+				next = u
+			}
+		}
+		nextLast, nextDepth := levels(next)
+		if nextDepth <= depth {
+			return start
+		}
+		start, last, depth = next, nextLast, nextDepth
+	}
+}
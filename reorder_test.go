@@ -0,0 +1,146 @@
+package golis_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+// scrambledGrid returns symmetric matrix of Laplace operator on
+// rectangle grid with random numbering of nodes
+func scrambledGrid(nx, ny int) *golis.SparseMatrixSymmetric {
+	size := nx * ny
+	p := rand.New(rand.NewSource(42)).Perm(size)
+	A := golis.NewSparseMatrixSymmetric(size)
+	add := func(i, j int, v float64) {
+		r, c := p[i], p[j]
+		if r > c {
+			r, c = c, r
+		}
+		A.Add(r, c, v)
+	}
+	for x := 0; x < nx; x++ {
+		for y := 0; y < ny; y++ {
+			i := x + y*nx
+			add(i, i, 4.0)
+			if x+1 < nx {
+				add(i, i+1, -1.0)
+			}
+			if y+1 < ny {
+				add(i, i+nx, -1.0)
+			}
+		}
+	}
+	return A
+}
+
+func TestRCM(t *testing.T) {
+	A := scrambledGrid(10, 6)
+	p := A.RCM()
+
+	// permutation is valid
+	used := make([]bool, len(p))
+	for _, v := range p {
+		if used[v] {
+			t.Fatalf("Not valid permutation: %v", p)
+		}
+		used[v] = true
+	}
+
+	B := A.Permute(p)
+	t.Logf("Bandwidth: %d -> %d", A.Bandwidth(), B.Bandwidth())
+	t.Logf("Profile  : %d -> %d", A.Profile(), B.Profile())
+	if B.Bandwidth() > 10 || B.Bandwidth() >= A.Bandwidth() {
+		t.Errorf("Bandwidth is not reduced: %d -> %d", A.Bandwidth(), B.Bandwidth())
+	}
+	if B.Profile() >= A.Profile() {
+		t.Errorf("Profile is not reduced: %d -> %d", A.Profile(), B.Profile())
+	}
+	if B.Profile() != B.Skyline().Profile() {
+		t.Errorf("Profile is not same with skyline: %d", B.Skyline().Profile())
+	}
+
+	// reordered matrix
+	n := len(p)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if B.At(i, j) != A.At(p[i], p[j]) {
+				t.Fatalf("Not valid element [%d,%d]", i, j)
+			}
+		}
+	}
+
+	// solution in original numbering
+	b := mat.NewDense(n, 1, nil)
+	for i := 0; i < n; i++ {
+		b.Set(i, 0, float64(i%7))
+	}
+	sky := B.Skyline()
+	if err := sky.Factorize(); err != nil {
+		t.Fatal(err)
+	}
+	y, err := sky.Solve(p.Permute(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := p.Restore(y)
+	if r := residual(A, x, b); r > 1e-10 {
+		t.Errorf("Residual is too big: %v", r)
+	}
+}
+
+func TestRCMSparseMatrix(t *testing.T) {
+	// non-symmetric pattern with two components
+	A := golis.NewSparseMatrix(6, 6)
+	for i := 0; i < 6; i++ {
+		A.Add(i, i, 1.0)
+	}
+	A.Add(0, 5, 2.0)
+	A.Add(3, 0, 3.0)
+	A.Add(1, 4, 4.0)
+	A.Add(4, 1, 5.0)
+
+	p := A.RCM()
+	B := A.Permute(p)
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 6; j++ {
+			if B.At(i, j) != A.At(p[i], p[j]) {
+				t.Fatalf("Not valid element [%d,%d]", i, j)
+			}
+		}
+	}
+	if A.Bandwidth() != 5 || B.Bandwidth() >= A.Bandwidth() {
+		t.Errorf("Bandwidth is not reduced: %d -> %d", A.Bandwidth(), B.Bandwidth())
+	}
+	if B.Profile() >= A.Profile() {
+		t.Errorf("Profile is not reduced: %d -> %d", A.Profile(), B.Profile())
+	}
+	inv := p.Inverse()
+	for i := range p {
+		if inv[p[i]] != i {
+			t.Fatalf("Not valid inverse permutation: %v %v", p, inv)
+		}
+	}
+}
+
+func TestRCMPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"NotSquare":   func() { golis.NewSparseMatrix(2, 3).RCM() },
+		"SizeMatrix":  func() { golis.NewSparseMatrix(2, 2).Permute(golis.Permutation{0}) },
+		"SizeVector":  func() { golis.Permutation{0}.Permute(mat.NewDense(2, 1, nil)) },
+		"SizeRestore": func() { golis.Permutation{0}.Restore(mat.NewDense(2, 1, nil)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				t.Logf("\n%v", r)
+				if r == nil {
+					t.Fatal("Haven`t panic for not valid data")
+				}
+			}()
+			f()
+		})
+	}
+}
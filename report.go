@@ -0,0 +1,209 @@
+package golis
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// SolverReport is parsed output of `lsolve` executable of `lis`
+// software or in-process solver.
+//
+// Example of output:
+//
+//	number of processes = 1
+//	max number of threads = 4
+//	number of threads = 4
+//	matrix size = 100 x 100 (460 nonzero entries)
+//
+//	initial vector x      : all components set to 0
+//	precision             : double
+//	linear solver         : BiCG
+//	preconditioner        : none
+//	convergence condition : ||b-Ax||_2 <= 1.0e-12 * ||b-Ax_0||_2
+//	matrix storage format : CSR
+//	linear solver status  : normal end
+//
+//	BiCG: number of iterations = 15
+//	BiCG:   double             = 15
+//	BiCG:   quad               = 0
+//	BiCG: elapsed time         = 5.178690e-03 sec.
+//	BiCG:   preconditioner     = 1.277685e-03 sec.
+//	BiCG:     matrix creation  = 1.254797e-03 sec.
+//	BiCG:   linear solver      = 3.901005e-03 sec.
+//	BiCG: relative residual    = 6.327297e-15
+type SolverReport struct {
+	Size                 int    // amount of rows of matrix
+	Threads              int    // amount of threads
+	InitialVector        string // description of initial vector x
+	Precision            string // for example: double, quad
+	Solver               string // name of linear solver, for example: BiCG
+	Preconditioner       string // name of preconditioner, for example: none
+	ConvergenceCondition string
+	Storage              string // matrix storage format, for example: CSR
+	Status               string // linear solver status, for example: normal end
+
+	Iterations         int           // amount of iterations
+	ElapsedTime        time.Duration // full time of solving
+	PreconditionerTime time.Duration // time of preconditioner creation
+	SolverTime         time.Duration // time of linear solver
+	RelativeResidual   float64       // relative residual at the end
+}
+
+// ParseSolverReport returns report parsed from output of `lsolve`
+// executable of `lis` software or in-process solver. Unknown lines
+// are ignored. If output have no linear solver, then error is returned.
+func ParseSolverReport(output string) (r SolverReport, err error) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	var line int
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if err = r.parseLine(text); err != nil {
+			return r, fmt.Errorf("Line %d `%s`: %v", line, text, err)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+	if r.Solver == "" {
+		err = fmt.Errorf("Linear solver is not found in output")
+	}
+	return
+}
+
+// SolveReport returns solution matrix of iterative solve for linear
+// system by solver s and report parsed from output of solver.
+// If s is nil, then function Solve is used. If b have several columns,
+// then report is about last column. See Solver and ParseSolverReport.
+//
+// Example:
+//
+//	x, rhistory, report, err := golis.SolveReport(golis.NativeSolver{}, A, b, "-i cg")
+//	if err != nil {
+//		...
+//	}
+//	if report.Iterations > 100 {
+//		...
+//	}
+func SolveReport(s Solver, A, b mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	report SolverReport,
+	err error) {
+
+	var output string
+	if s == nil {
+		solution, rhistory, output, err = Solve(A, b, options)
+	} else {
+		solution, rhistory, output, err = s.Solve(A, b, options)
+	}
+	if err != nil {
+		return
+	}
+	report, err = ParseSolverReport(output)
+	return
+}
+
+// parseLine parses one line of output
+func (r *SolverReport) parseLine(text string) (err error) {
+	// line of solver results, for example:
+	// BiCG: number of iterations = 15
+	if r.Solver != "" && strings.HasPrefix(text, r.Solver+":") {
+		text = strings.TrimPrefix(text, r.Solver+":")
+		// indent is important for preconditioner time
+		// and linear solver time
+		name, value := split(text, "=")
+		value = strings.TrimSpace(strings.TrimSuffix(value, "sec."))
+		switch strings.TrimSpace(name) {
+		case "number of iterations":
+			r.Iterations, err = strconv.Atoi(value)
+		case "elapsed time":
+			r.ElapsedTime, err = parseSeconds(value)
+		case "preconditioner":
+			if strings.HasPrefix(name, "   ") {
+				r.PreconditionerTime, err = parseSeconds(value)
+			}
+		case "linear solver":
+			if strings.HasPrefix(name, "   ") {
+				r.SolverTime, err = parseSeconds(value)
+			}
+		case "relative residual":
+			r.RelativeResidual, err = strconv.ParseFloat(value, 64)
+		}
+		return
+	}
+
+	// lines with ":", for example:
+	// linear solver         : BiCG
+	if strings.Contains(text, " : ") {
+		name, value := split(text, " : ")
+		switch name {
+		case "initial vector x":
+			r.InitialVector = value
+		case "precision":
+			r.Precision = value
+		case "linear solver":
+			r.Solver = value
+		case "preconditioner":
+			r.Preconditioner = value
+		case "convergence condition":
+			r.ConvergenceCondition = value
+		case "matrix storage format":
+			r.Storage = value
+		case "linear solver status":
+			r.Status = value
+		}
+		return
+	}
+
+	// lines with "=", for example:
+	// matrix size = 100 x 100 (460 nonzero entries)
+	name, value := split(text, "=")
+	switch name {
+	case "number of threads":
+		r.Threads, err = strconv.Atoi(value)
+	case "matrix size":
+		if fields := strings.Fields(value); len(fields) > 0 {
+			r.Size, err = strconv.Atoi(fields[0])
+		}
+	}
+	return
+}
+
+// Err returns error value of linear solver status.
+// For normal end returns nil.
+func (r SolverReport) Err() error {
+	for i, e := range errorStrings {
+		if strings.Contains(r.Status, e) {
+			return ErrorValue(i)
+		}
+	}
+	return nil
+}
+
+// split returns trimmed name and value separated by first sep.
+// Spaces before name are not trimmed.
+func split(text, sep string) (name, value string) {
+	index := strings.Index(text, sep)
+	if index < 0 {
+		return text, ""
+	}
+	return strings.TrimRight(text[:index], " "), strings.TrimSpace(text[index+len(sep):])
+}
+
+// parseSeconds returns duration parsed from seconds
+func parseSeconds(value string) (time.Duration, error) {
+	s, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(s * float64(time.Second)), nil
+}
@@ -0,0 +1,157 @@
+package golis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestParseSolverReport(t *testing.T) {
+	output := `
+number of processes = 1
+max number of threads = 4
+number of threads = 4
+matrix size = 100 x 100 (460 nonzero entries)
+
+initial vector x      : all components set to 0
+precision             : double
+linear solver         : BiCG
+preconditioner        : ILU(0)
+convergence condition : ||b-Ax||_2 <= 1.0e-12 * ||b-Ax_0||_2
+matrix storage format : CSR
+linear solver status  : normal end
+
+BiCG: number of iterations = 15
+BiCG:   double             = 15
+BiCG:   quad               = 0
+BiCG: elapsed time         = 5.178690e-03 sec.
+BiCG:   preconditioner     = 1.277685e-03 sec.
+BiCG:     matrix creation  = 1.254797e-03 sec.
+BiCG:   linear solver      = 3.901005e-03 sec.
+BiCG: relative residual    = 6.327297e-15
+`
+	r, err := golis.ParseSolverReport(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := golis.SolverReport{
+		Size:                 100,
+		Threads:              4,
+		InitialVector:        "all components set to 0",
+		Precision:            "double",
+		Solver:               "BiCG",
+		Preconditioner:       "ILU(0)",
+		ConvergenceCondition: "||b-Ax||_2 <= 1.0e-12 * ||b-Ax_0||_2",
+		Storage:              "CSR",
+		Status:               "normal end",
+		Iterations:           15,
+		ElapsedTime:          5178690 * time.Nanosecond,
+		PreconditionerTime:   1277685 * time.Nanosecond,
+		SolverTime:           3901005 * time.Nanosecond,
+		RelativeResidual:     6.327297e-15,
+	}
+	if r != expect {
+		t.Fatalf("Not same:\n%#v\n%#v", r, expect)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("Not valid error: %v", err)
+	}
+}
+
+func TestParseSolverReportFail(t *testing.T) {
+	r, err := golis.ParseSolverReport(`
+linear solver         : CG
+linear solver status  : LIS_MAXITER(code=4)
+CG: number of iterations = 1001
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Err() != golis.Maxiter || r.Iterations != 1001 {
+		t.Fatalf("Not valid report: %#v", r)
+	}
+
+	for _, output := range []string{
+		"",
+		"matrix size = 2 x 2\n",
+		"linear solver : CG\nCG: number of iterations = many\n",
+		"linear solver : CG\nCG: relative residual = wrong\n",
+		"number of threads = ?\n",
+	} {
+		if _, err := golis.ParseSolverReport(output); err == nil {
+			t.Errorf("Haven`t error for output:\n%s", output)
+		} else {
+			t.Log(err)
+		}
+	}
+}
+
+func TestParseSolverReportNative(t *testing.T) {
+	A := convectionDiffusion(20)
+	b := mat.NewDense(20, 1, nil)
+	for i := 0; i < 20; i++ {
+		b.Set(i, 0, 1.0)
+	}
+	_, rhistory, output, err := golis.LsolveNative(A, b, "-i gmres -tol 1e-10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := golis.ParseSolverReport(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Solver != "GMRES" || r.Size != 20 || r.Threads != 1 || r.Preconditioner != "none" ||
+		r.Precision != "double" || r.Status != "normal end" ||
+		r.Iterations != len(rhistory)-1 ||
+		r.RelativeResidual > 1e-10 || r.SolverTime > r.ElapsedTime ||
+		r.ConvergenceCondition != "||b-Ax||_2 <= 1.0e-10 * ||b||_2" {
+		t.Fatalf("Not valid report: %#v", r)
+	}
+
+	// status of failed solver
+	_, rhistory, output, err = golis.LsolveNative(A, b, "-i gmres -maxiter 2")
+	if err != golis.Maxiter {
+		t.Fatalf("Not correct error: %v", err)
+	}
+	if r, err = golis.ParseSolverReport(output); err != nil {
+		t.Fatal(err)
+	}
+	if r.Err() != golis.Maxiter || r.Status != "LIS_MAXITER(code=4)" ||
+		r.Iterations != len(rhistory)-1 {
+		t.Fatalf("Not valid report: %#v", r)
+	}
+}
+
+func TestSolveReport(t *testing.T) {
+	A := convectionDiffusion(20)
+	b := mat.NewDense(20, 1, nil)
+	for i := 0; i < 20; i++ {
+		b.Set(i, 0, 1.0)
+	}
+	_, rhistory, r, err := golis.SolveReport(golis.NativeSolver{}, A, b, "-i bicgstab -p jacobi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Solver != "BiCGSTAB" || r.Preconditioner != "Jacobi" ||
+		r.Iterations != len(rhistory)-1 || r.Err() != nil {
+		t.Fatalf("Not valid report: %#v", r)
+	}
+
+	// default solver
+	defer func(s golis.Solver) { golis.DefaultSolver = s }(golis.DefaultSolver)
+	golis.DefaultSolver = golis.NativeSolver{}
+	if _, _, r, err = golis.SolveReport(nil, A, b, "-i gmres"); err != nil || r.Solver != "GMRES" {
+		t.Fatalf("Not valid report of default solver: %v, %#v", err, r)
+	}
+
+	// output without report
+	s := golis.SolverFunc(func(A, b mat.Matrix, options string) (
+		mat.Matrix, []float64, string, error) {
+		return b, nil, "", nil
+	})
+	if _, _, _, err = golis.SolveReport(s, A, b, ""); err == nil {
+		t.Fatalf("Haven`t error for output without report")
+	}
+}
@@ -0,0 +1,128 @@
+package golis
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// PivotError is error of factorization for not positive pivot
+type PivotError struct {
+	Row   int     // index of row with not positive pivot
+	Value float64 // value of pivot
+}
+
+func (e PivotError) Error() string {
+	return fmt.Sprintf("Not positive pivot in row %d: %v", e.Row, e.Value)
+}
+
+// Factorize is in-place LDLᵀ (Cholesky) factorization of symmetric
+// positive-definite matrix in skyline format.
+//
+//	A = Uᵀ * D * U
+//
+// Where: U is unit upper triangular matrix, D is diagonal matrix.
+// After factorization values of matrix are replaced by elements of
+// U and D and matrix cannot be changed, method At returns elements
+// of factors. Fill-in of factorization is only inside skyline profile,
+// so additional memory is not allocated.
+//
+// If pivot is not positive, then error PivotError is returned. In that
+// case matrix is partly factorized, so matrix cannot be changed and
+// solved, method Solve returns same error.
+func (m *SkylineSymmetricMatrix) Factorize() error {
+	if m.failed != nil {
+		return m.failed
+	}
+	if m.factorized {
+		return fmt.Errorf("Matrix is factorized")
+	}
+	for j := range m.sc {
+		cj := &m.sc[j]
+
+		// reduce elements of column
+		for i := cj.azv; i < j; i++ {
+			ci := &m.sc[i]
+			k0 := ci.azv
+			if k0 < cj.azv {
+				k0 = cj.azv
+			}
+			var sum float64
+			for k := k0; k < i; k++ {
+				sum += ci.d[k-ci.azv] * cj.d[k-cj.azv]
+			}
+			cj.d[i-cj.azv] -= sum
+		}
+
+		// divide by pivots and reduce diagonal element
+		diag := len(cj.d) - 1
+		for i := cj.azv; i < j; i++ {
+			g := cj.d[i-cj.azv]
+			l := g / m.sc[i].d[len(m.sc[i].d)-1]
+			cj.d[i-cj.azv] = l
+			cj.d[diag] -= g * l
+		}
+
+		if !(cj.d[diag] > 0.0) {
+			m.failed = PivotError{Row: j, Value: cj.d[diag]}
+			return m.failed
+		}
+	}
+	m.factorized = true
+	return nil
+}
+
+// Solve returns solution of linear system by factorized matrix
+//
+//	A * x = b
+//
+// Where: b is matrix of right-hand vectors in columns. Solution have
+// same sizes as b. Matrix must be factorized before, see Factorize.
+// If factorization is failed, then error of factorization is returned.
+func (m *SkylineSymmetricMatrix) Solve(b mat.Matrix) (*mat.Dense, error) {
+	if m.failed != nil {
+		return nil, m.failed
+	}
+	if !m.factorized {
+		return nil, fmt.Errorf("Matrix is not factorized")
+	}
+	rb, cb := b.Dims()
+	if rb != m.size {
+		return nil, fmt.Errorf("Amount of matrix and vector b is not same: %d != %d", m.size, rb)
+	}
+
+	x := mat.NewDense(rb, cb, nil)
+	y := make([]float64, rb)
+	for col := 0; col < cb; col++ {
+		for i := range y {
+			y[i] = b.At(i, col)
+		}
+		m.substitution(y)
+		x.SetCol(col, y)
+	}
+	return x, nil
+}
+
+// substitution solves system in place by forward and back substitution
+func (m *SkylineSymmetricMatrix) substitution(y []float64) {
+	// forward substitution: Uᵀ * z = b
+	for j := range m.sc {
+		cj := &m.sc[j]
+		var sum float64
+		for k := cj.azv; k < j; k++ {
+			sum += cj.d[k-cj.azv] * y[k]
+		}
+		y[j] -= sum
+	}
+	// diagonal: D * w = z
+	for j := range m.sc {
+		y[j] /= m.sc[j].d[len(m.sc[j].d)-1]
+	}
+	// back substitution: U * x = w
+	for j := len(m.sc) - 1; j >= 0; j-- {
+		cj := &m.sc[j]
+		for k := cj.azv; k < j; k++ {
+			y[k] -= cj.d[k-cj.azv] * y[j]
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package golis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSkylineFactorize(t *testing.T) {
+	for _, size := range []int{1, 2, 7, 40} {
+		t.Run(fmt.Sprintf("Size%d", size), func(t *testing.T) {
+			// symmetric positive-definite matrix with variable profile
+			sp := golis.NewSparseMatrixSymmetric(size)
+			for i := 0; i < size; i++ {
+				sp.Add(i, i, float64(size))
+				if j := i + 1 + i%4; j < size {
+					sp.Add(i, j, -1.0)
+				}
+				if i+1 < size {
+					sp.Add(i, i+1, 0.5)
+				}
+			}
+			A := mat.DenseCopyOf(sp)
+
+			b := mat.NewDense(size, 3, nil)
+			for i := 0; i < size; i++ {
+				b.Set(i, 0, 1.0)
+				b.Set(i, 1, float64(i))
+				b.Set(i, 2, float64(size-i)*0.1)
+			}
+
+			sky := sp.Skyline()
+			if err := sky.Factorize(); err != nil {
+				t.Fatal(err)
+			}
+			x, err := sky.Solve(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expect mat.Dense
+			if err := expect.Solve(A, b); err != nil {
+				t.Fatal(err)
+			}
+			if !mat.EqualApprox(x, &expect, 1e-10) {
+				t.Fatalf("Not same solution:\n%v\n%v",
+					mat.Formatted(x), mat.Formatted(&expect))
+			}
+		})
+	}
+}
+
+func TestSkylineFactorizeFail(t *testing.T) {
+	t.Run("Pivot", func(t *testing.T) {
+		sky := golis.NewSkylineSymmetricMatrix(3)
+		sky.Add(0, 0, 1.0)
+		sky.Add(0, 1, 2.0)
+		sky.Add(1, 1, 1.0)
+		sky.Add(2, 2, 1.0)
+		err := sky.Factorize()
+		pe, ok := err.(golis.PivotError)
+		if !ok {
+			t.Fatalf("Not valid error: %v", err)
+		}
+		t.Logf("%v", pe)
+		if pe.Row != 1 || pe.Value != -3.0 {
+			t.Errorf("Not valid pivot: %#v", pe)
+		}
+
+		// partly factorized matrix cannot be solved
+		if _, err := sky.Solve(mat.NewDense(3, 1, []float64{1, 1, 1})); err != pe {
+			t.Errorf("Not valid error of Solve: %v", err)
+		}
+		if err := sky.Factorize(); err != pe {
+			t.Errorf("Not valid error of second Factorize: %v", err)
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("Haven`t panic for partly factorized matrix")
+			}
+		}()
+		sky.Add(2, 2, 1.0)
+	})
+
+	t.Run("ZeroPivot", func(t *testing.T) {
+		sky := golis.NewSkylineSymmetricMatrix(2)
+		sky.Add(0, 0, 1.0)
+		err := sky.Factorize()
+		if pe, ok := err.(golis.PivotError); !ok || pe.Row != 1 {
+			t.Fatalf("Not valid error: %v", err)
+		}
+	})
+
+	t.Run("NotFactorized", func(t *testing.T) {
+		sky := golis.NewSkylineSymmetricMatrix(2)
+		if _, err := sky.Solve(mat.NewDense(2, 1, nil)); err == nil {
+			t.Fatalf("Haven`t error : %v", err)
+		}
+	})
+
+	t.Run("Twice", func(t *testing.T) {
+		sky := golis.NewSkylineSymmetricMatrix(2)
+		sky.Add(0, 0, 1.0)
+		sky.Add(1, 1, 1.0)
+		if err := sky.Factorize(); err != nil {
+			t.Fatal(err)
+		}
+		if err := sky.Factorize(); err == nil {
+			t.Fatalf("Haven`t error : %v", err)
+		}
+		if _, err := sky.Solve(mat.NewDense(3, 1, nil)); err == nil {
+			t.Fatalf("Haven`t error : %v", err)
+		}
+		defer func() {
+			r := recover()
+			t.Logf("\n%v", r)
+			if r == nil {
+				t.Fatal("Haven`t panic for factorized matrix")
+			}
+		}()
+		sky.Add(0, 1, 1.0)
+	})
+}
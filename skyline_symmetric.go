@@ -1,50 +1,276 @@
 package golis
 
+import (
+	"fmt"
+
+	"github.com/Konstantin8105/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// guarantee SkylineSymmetricMatrix have interface of gonum.mat.MutableSymmetric
+var _ mat.MutableSymmetric = (*SkylineSymmetricMatrix)(nil)
+
+// SkylineSymmetricMatrix storage symmetric matrix in skyline format
+type SkylineSymmetricMatrix struct {
+	size int // amount of element of square matrix
+	sc   []skylineColumn
+
+	factorized bool  // values are replaced by factorization
+	failed     error // error of failed factorization
+}
+
+// skylineColumn is struct in skyline format of column.
 //
-// // guarantee have interface of gonum.mat.Matrix
-// var _ mat.Matrix = (*SkylineSymmetricMatrix)(nil)
-//
-// // SkylineSymmetricMatrix storage symmetric matrix in skyline format
-// type SkylineSymmetricMatrix struct {
-// 	size int // amount of element of square matrix
-// 	sc   []skylineColumn
-// }
-//
-// // skylineColumn is struct in skyline format of column.
-// //
-// // Example :
-// // We have column with size from 0 to diagonal is 10.
-// //
-// // Element of column [ 0 0 0 0 0 1 2 3 4 5 ].
-// // So in skylineColumn strorage {azv: 6, d:[1 2 3 4 5]}
-// //
-// // Element of column [ 1 2 3 4 5 6 7 8 9 10].
-// // So in skylineColumn strorage {azv: 0, d:[1 2 3 4 5 6 7 8 9 10]}
-// type skylineColumn struct {
-// 	azv int       // amount zero values in column or position of first non-zero row in column
-// 	d   []float64 // data from azv row to diagonal
-// }
+// Example :
+// We have column with size from 0 to diagonal is 10.
 //
-// func NewSkylineSymmetricMatrix(size int) *SkylineSymmetricMatrix {
-// 	var et errors.Tree
-// 	et.Name = "Check size of matrix"
-// 	if size < 0 {
-// 		et.Add(fmt.Errorf("Size of matrix cannot be less zero : %d", size))
-// 	}
-// 	if size == 0 {
-// 		et.Add(fmt.Errorf("Size of matrix cannot be zero"))
-// 	}
-// 	if et.IsError() {
-// 		panic(et)
-// 	}
+// Element of column [ 0 0 0 0 0 1 2 3 4 5 ].
+// So in skylineColumn strorage {azv: 5, d:[1 2 3 4 5]}
 //
-// 	m := new(SparseMatrix)
-// 	m.size = size
-// 	m.sc = make([]skylineColumn, size)
-// 	// allocate memory
-// 	minimalWidth := 6
-// 	for i := range m.sc {
-// 		m.sc[i].azv = i
-// 		m.sc[i].d = make([]float64, 0, minimalWidth)
-// 	}
-// }
+// Element of column [ 1 2 3 4 5 6 7 8 9 10].
+// So in skylineColumn strorage {azv: 0, d:[1 2 3 4 5 6 7 8 9 10]}
+type skylineColumn struct {
+	azv int       // amount zero values in column or position of first non-zero row in column
+	d   []float64 // data from azv row to diagonal
+}
+
+// minimalWidth is minimal capacity of skyline column
+const minimalWidth = 6
+
+// NewSkylineSymmetricMatrix return new skyline symmetric matrix
+func NewSkylineSymmetricMatrix(size int) *SkylineSymmetricMatrix {
+	var et errors.Tree
+	et.Name = "Check size of matrix"
+	if size < 0 {
+		et.Add(fmt.Errorf("Size of matrix cannot be less zero : %d", size))
+	}
+	if size == 0 {
+		et.Add(fmt.Errorf("Size of matrix cannot be zero"))
+	}
+	if et.IsError() {
+		panic(et)
+	}
+
+	m := new(SkylineSymmetricMatrix)
+	m.size = size
+	m.sc = make([]skylineColumn, size)
+	// allocate memory
+	for i := range m.sc {
+		m.sc[i].azv = i
+		m.sc[i].d = make([]float64, 1, minimalWidth)
+	}
+	return m
+}
+
+// At returns the value of a matrix element at row i, column j.
+// It will panic if i or j are out of bounds for the matrix.
+// After factorization elements of factors U and D are returned,
+// but not values of original matrix, see Factorize.
+func (m *SkylineSymmetricMatrix) At(r, c int) float64 {
+	m.check(r, c)
+	if r > c {
+		r, c = c, r
+	}
+	if r < m.sc[c].azv {
+		return 0.0
+	}
+	return m.sc[c].d[r-m.sc[c].azv]
+}
+
+// Dims returns the dimensions of a Matrix.
+// Where: r - amount of rows, c - amount of columns.
+func (m *SkylineSymmetricMatrix) Dims() (r, c int) {
+	return m.size, m.size
+}
+
+// T returns the transpose of the Matrix.
+// Symmetric matrix is returned without copy.
+func (m *SkylineSymmetricMatrix) T() mat.Matrix {
+	return m
+}
+
+// Symmetric returns the number of rows/columns in the matrix.
+func (m *SkylineSymmetricMatrix) Symmetric() int {
+	return m.size
+}
+
+// SetSym set value in skyline matrix by address [r,c].
+// If r,c outside of matrix, then create a panic.
+// If value is not valid, then create panic.
+// Profile of column is increased, if row is upper of skyline.
+func (m *SkylineSymmetricMatrix) SetSym(r, c int, value float64) {
+	m.check(r, c)
+	m.checkFactorized()
+	if r > c {
+		panic(fmt.Errorf("SkylineSymmetricMatrix have only upper value: %d <= %d", r, c))
+	}
+	checkValue(value)
+	if r < m.sc[c].azv {
+		if value == 0.0 {
+			return
+		}
+		m.grow(r, c)
+	}
+	m.sc[c].d[r-m.sc[c].azv] = value
+}
+
+// Add is alternative of pattern m.SetSym(r,c, someValue + m.At(r,c)).
+// Addition value to matrix element
+func (m *SkylineSymmetricMatrix) Add(r, c int, value float64) {
+	m.check(r, c)
+	m.checkFactorized()
+	if r > c {
+		panic(fmt.Errorf("SkylineSymmetricMatrix have only upper value: %d <= %d", r, c))
+	}
+	checkValue(value)
+	if value == 0.0 { // no need addition zero value
+		return
+	}
+	if r < m.sc[c].azv {
+		m.grow(r, c)
+	}
+	m.sc[c].d[r-m.sc[c].azv] += value
+}
+
+// grow increase profile of column c up to row r
+func (m *SkylineSymmetricMatrix) grow(r, c int) {
+	col := &m.sc[c]
+	size := c - r + 1
+	if size <= cap(col.d) {
+		// moving data inside allocated memory
+		d := col.d[:size]
+		shift := size - len(col.d)
+		copy(d[shift:], col.d)
+		for i := 0; i < shift; i++ {
+			d[i] = 0.0
+		}
+		col.d = d
+	} else {
+		d := make([]float64, size)
+		copy(d[size-len(col.d):], col.d)
+		col.d = d
+	}
+	col.azv = r
+}
+
+// SetZeroForRowColumn set zero for all matrix element on
+// row and column `rc`
+func (m *SkylineSymmetricMatrix) SetZeroForRowColumn(rc int) {
+	m.check(rc, rc)
+	m.checkFactorized()
+	// zero on column
+	for i := range m.sc[rc].d {
+		m.sc[rc].d[i] = 0.0
+	}
+	// zero on row
+	for c := rc + 1; c < m.size; c++ {
+		if rc < m.sc[c].azv {
+			continue
+		}
+		m.sc[c].d[rc-m.sc[c].azv] = 0.0
+	}
+}
+
+// Profile returns amount of stored elements in skyline of matrix
+func (m *SkylineSymmetricMatrix) Profile() int {
+	var p int
+	for i := range m.sc {
+		p += len(m.sc[i].d)
+	}
+	return p
+}
+
+func (m *SkylineSymmetricMatrix) check(r, c int) {
+	var et errors.Tree
+	et.Name = "Check input indexes of element"
+
+	if r < 0 {
+		et.Add(fmt.Errorf("Index of rows cannot be less zero : %d", r))
+	}
+	if r >= m.size {
+		et.Add(fmt.Errorf("Index of rows is outside of matrix: %d of %d", r, m.size))
+	}
+	if c < 0 {
+		et.Add(fmt.Errorf("Index of columns cannot be less zero : %d", c))
+	}
+	if c >= m.size {
+		et.Add(fmt.Errorf("Index of columns is outside of matrix: %d of %d", c, m.size))
+	}
+	if et.IsError() {
+		panic(et)
+	}
+}
+
+// checkFactorized is panic if matrix is factorized,
+// also in case of failed factorization
+func (m *SkylineSymmetricMatrix) checkFactorized() {
+	if m.factorized || m.failed != nil {
+		panic("Factorized matrix cannot be changed")
+	}
+}
+
+// String return standard golis string of skyline matrix
+func (m *SkylineSymmetricMatrix) String() string {
+	s := "\n"
+	s += fmt.Sprintf("Amount of rows    : %5d\n", m.size)
+	s += fmt.Sprintf("Amount of columns : %5d\n", m.size)
+	s += fmt.Sprintf("%-6s %-6s %20s\n", "row", "column", "value")
+	for c := range m.sc {
+		for i, v := range m.sc[c].d {
+			if v == 0.0 {
+				continue
+			}
+			s += fmt.Sprintf("%-6d %-6d %-20.15e\n", m.sc[c].azv+i, c, v)
+		}
+	}
+	return s
+}
+
+// Skyline returns matrix in skyline format with same values.
+// Profile of skyline matrix is calculated before allocation.
+func (m *SparseMatrixSymmetric) Skyline() *SkylineSymmetricMatrix {
+	m.s.compress()
+	sky := new(SkylineSymmetricMatrix)
+	sky.size = m.s.r
+	sky.sc = make([]skylineColumn, sky.size)
+	for i := range sky.sc {
+		sky.sc[i].azv = i
+	}
+	// calculate profile
+	for i := range m.s.data.ts {
+		r := int(m.s.data.ts[i].position % int64(m.s.r))
+		c := int(m.s.data.ts[i].position / int64(m.s.r))
+		if r < sky.sc[c].azv {
+			sky.sc[c].azv = r
+		}
+	}
+	// allocate memory
+	for i := range sky.sc {
+		sky.sc[i].d = make([]float64, i-sky.sc[i].azv+1)
+	}
+	// store values
+	for i := range m.s.data.ts {
+		r := int(m.s.data.ts[i].position % int64(m.s.r))
+		c := int(m.s.data.ts[i].position / int64(m.s.r))
+		sky.sc[c].d[r-sky.sc[c].azv] = m.s.data.ts[i].d
+	}
+	return sky
+}
+
+// Sparse returns sparse matrix with non-zero values of skyline matrix
+func (m *SkylineSymmetricMatrix) Sparse() *SparseMatrixSymmetric {
+	s := NewSparseMatrixSymmetric(m.size)
+	// columns are added in order of positions in sparse matrix
+	for c := range m.sc {
+		for i, v := range m.sc[c].d {
+			if v == 0.0 {
+				continue
+			}
+			s.s.data.ts = s.s.appendTriple(s.s.data.ts, triple{
+				position: int64(m.sc[c].azv+i) + int64(c)*int64(m.size),
+				d:        v,
+			})
+		}
+	}
+	return s
+}
@@ -0,0 +1,275 @@
+package golis_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSkylineSymmetricMatrix(t *testing.T) {
+	a := mat.NewDense(3, 3, []float64{
+		8, 1, 6,
+		1, 5, 7,
+		6, 7, 2,
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		s := golis.NewSkylineSymmetricMatrix(3)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				if i > j {
+					continue
+				}
+				s.Add(i, j, a.At(i, j)/2.0)
+				s.Add(i, j, a.At(i, j)/2.0)
+			}
+		}
+		if !isSame(s, a) {
+			t.Fatalf("Value is not same:\n%s\n%#v", s, a)
+		}
+	})
+
+	t.Run("Add up-down-up", func(t *testing.T) {
+		s := golis.NewSkylineSymmetricMatrix(3)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				if i > j {
+					continue
+				}
+				s.Add(i, j, a.At(i, j)/2.0)
+				s.Add(i, j, a.At(i, j)/2.0)
+				s.Add(i, j, a.At(i, j)/2.0)
+			}
+		}
+		for i := 2; i >= 0; i-- {
+			for j := 2; j >= 0; j-- {
+				if i > j {
+					continue
+				}
+				s.Add(i, j, -a.At(i, j)/2.0)
+				s.Add(i, j, 0.0)
+				s.Add(i, j, -a.At(i, j)/2.0)
+			}
+		}
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				if i > j {
+					continue
+				}
+				s.Add(i, j, -a.At(i, j)/2.0)
+				s.Add(i, j, a.At(i, j)/2.0)
+				s.Add(i, j, a.At(i, j)/2.0)
+			}
+		}
+		if !isSame(s, a) {
+			t.Fatalf("Value is not same:\n%s\n%#v", s, a)
+		}
+	})
+
+	t.Run("Add reverse", func(t *testing.T) {
+		s := golis.NewSkylineSymmetricMatrix(3)
+		for i := 2; i >= 0; i-- {
+			for j := 2; j >= 0; j-- {
+				if i > j {
+					continue
+				}
+				s.Add(i, j, a.At(i, j)/2.0)
+				s.Add(i, j, a.At(i, j)/2.0)
+			}
+		}
+		if !isSame(s, a) {
+			t.Fatalf("Value is not same:\n%s\n%#v", s, a)
+		}
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		s := golis.NewSkylineSymmetricMatrix(3)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				if i > j {
+					continue
+				}
+				s.SetSym(i, j, a.At(i, j))
+				s.SetSym(i, j, a.At(i, j))
+			}
+		}
+		if !isSame(s, a) {
+			t.Fatalf("Value is not same:\n%s\n%#v", s, a)
+		}
+	})
+
+	t.Run("Set reverse", func(t *testing.T) {
+		s := golis.NewSkylineSymmetricMatrix(3)
+		for i := 2; i >= 0; i-- {
+			for j := 2; j >= 0; j-- {
+				if i > j {
+					continue
+				}
+				s.SetSym(i, j, a.At(i, j))
+				s.SetSym(i, j, a.At(i, j))
+			}
+		}
+		if !isSame(s, a) {
+			t.Fatalf("Value is not same:\n%s\n%#v", s, a)
+		}
+	})
+
+	t.Run("Matrix with zero values", func(t *testing.T) {
+		a := mat.NewDense(3, 3, make([]float64, 9))
+		s := golis.NewSkylineSymmetricMatrix(3)
+		if !isSame(s, a) {
+			t.Fatalf("Value is not same:\n%s\n%#v", s, a)
+		}
+		if p := s.Profile(); p != 3 {
+			t.Errorf("Not valid profile: %d", p)
+		}
+	})
+
+	t.Run("Sparse matrix", func(t *testing.T) {
+		a := mat.NewDense(3, 3, make([]float64, 9))
+		a.Set(1, 1, 42)
+		s := golis.NewSkylineSymmetricMatrix(3)
+		s.SetSym(1, 1, 42)
+		s.SetSym(0, 2, 0.0)
+		if !isSame(s, a) {
+			t.Fatalf("Value is not same:\n%s\n%#v", s, a)
+		}
+		if p := s.Profile(); p != 3 {
+			t.Errorf("Profile is increased by zero value: %d", p)
+		}
+	})
+
+	t.Run("Profile growth", func(t *testing.T) {
+		size := 20
+		a := mat.NewDense(size, size, nil)
+		s := golis.NewSkylineSymmetricMatrix(size)
+		for _, e := range []struct{ r, c int }{
+			{18, 19}, {10, 19}, {15, 19}, {0, 19}, {3, 5}, {2, 5},
+		} {
+			v := float64(e.r + e.c)
+			s.Add(e.r, e.c, v)
+			a.Set(e.r, e.c, v)
+			a.Set(e.c, e.r, v)
+		}
+		if !isSame(s, a) {
+			t.Fatalf("Value is not same:\n%s\n%v", s, mat.Formatted(a))
+		}
+		if p := s.Profile(); p != size-2+20+4 {
+			t.Errorf("Not valid profile: %d", p)
+		}
+	})
+
+	t.Run("Transpose", func(t *testing.T) {
+		s := golis.NewSkylineSymmetricMatrix(3)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				if i > j {
+					continue
+				}
+				s.SetSym(i, j, a.At(i, j))
+			}
+		}
+		stt := s.T().T()
+		if !isSame(stt, a) {
+			t.Fatalf("Value is not same:\n%s\n%#v", stt, a)
+		}
+	})
+
+	t.Run("String empty matrix", func(t *testing.T) {
+		s := golis.NewSkylineSymmetricMatrix(3)
+		if len(s.String()) == 0 {
+			t.Fatalf("String for empty matrix is empty")
+		}
+	})
+
+	t.Run("SetZeroForRowColumn", func(t *testing.T) {
+		a := mat.NewDense(3, 3, []float64{
+			8, 1, 6,
+			1, 5, 7,
+			6, 7, 2,
+		})
+		s := golis.NewSkylineSymmetricMatrix(3)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				if i > j {
+					continue
+				}
+				s.Add(i, j, a.At(i, j))
+			}
+		}
+		for _, rc := range []int{0, 2} {
+			for i := 0; i < 3; i++ {
+				a.Set(rc, i, 0.0)
+				a.Set(i, rc, 0.0)
+			}
+			s.SetZeroForRowColumn(rc)
+		}
+		if !isSame(s, a) {
+			t.Fatalf("Value is not same:\n%s\n%#v", s, a)
+		}
+	})
+
+	t.Run("Conversion", func(t *testing.T) {
+		size := 30
+		sp := golis.NewSparseMatrixSymmetric(size)
+		for i := 0; i < size; i++ {
+			sp.Add(i, i, 4.0)
+			if j := i + 1 + i%5; j < size {
+				sp.Add(i, j, -float64(i))
+			}
+		}
+		sky := sp.Skyline()
+		if !isSame(sky, sp) {
+			t.Fatalf("Value is not same:\n%s\n%s", sky, sp)
+		}
+		back := sky.Sparse()
+		if !isSame(back, sp) {
+			t.Fatalf("Value is not same:\n%s\n%s", back, sp)
+		}
+		back.Add(0, 1, 1.0)
+		if back.At(0, 1) != sp.At(0, 1)+1.0 {
+			t.Fatalf("Not valid sparse matrix after conversion")
+		}
+	})
+}
+
+func TestSkylineSymmetricMatrixPanics(t *testing.T) {
+	for i, size := range []int{0, -1} {
+		t.Run(fmt.Sprintf("Panic%d", i), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				t.Logf("\n%v", r)
+				if r == nil {
+					t.Fatal("Haven`t panic for not valid data")
+				}
+			}()
+			_ = golis.NewSkylineSymmetricMatrix(size)
+		})
+	}
+
+	s := golis.NewSkylineSymmetricMatrix(3)
+	for i, f := range []func(){
+		func() { _ = s.At(-1, 1) },
+		func() { _ = s.At(1, 5) },
+		func() { s.SetSym(1, 0, 1.0) },
+		func() { s.SetSym(0, 3, 1.0) },
+		func() { s.SetSym(0, 0, math.NaN()) },
+		func() { s.Add(2, 1, 1.0) },
+		func() { s.Add(0, 0, math.Inf(1)) },
+		func() { s.SetZeroForRowColumn(5) },
+	} {
+		t.Run(fmt.Sprintf("PanicFunc%d", i), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				t.Logf("\n%v", r)
+				if r == nil {
+					t.Fatal("Haven`t panic for not valid data")
+				}
+			}()
+			f()
+		})
+	}
+}
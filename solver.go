@@ -0,0 +1,276 @@
+package golis
+
+import (
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Solver is interface of linear system solver.
+//
+//	A * x = b
+//
+// Where: A is matrix, b is right-hand vector, options is options
+// in `lis` syntax. Results are same as in function Lsolve.
+type Solver interface {
+	Solve(A, b mat.Matrix, options string) (
+		solution mat.Matrix,
+		rhistory []float64,
+		output string,
+		err error)
+}
+
+// MultipleSolver is interface of solver for linear systems with same
+// matrix and several right-hand vectors.
+//
+//	A * X = B
+//
+// Where: A is matrix, B is matrix with right-hand vectors in columns.
+// Results are same as in function LsolveMultiple.
+type MultipleSolver interface {
+	SolveMultiple(A, B mat.Matrix, options string) (
+		solution mat.Matrix,
+		rhistory [][]float64,
+		output []string,
+		err error)
+}
+
+// InitialSolver is interface of solver for linear system with
+// initial guess x0 of solution. Results are same as in function
+// LsolveInitial.
+type InitialSolver interface {
+	SolveInitial(A, b, x0 mat.Matrix, options string) (
+		solution mat.Matrix,
+		rhistory []float64,
+		output string,
+		err error)
+}
+
+// guarantee solvers have interface of Solver
+var (
+	_ Solver = LisSolver{}
+	_ Solver = NativeSolver{}
+	_ Solver = SolverFunc(nil)
+
+	_ MultipleSolver = LisSolver{}
+	_ MultipleSolver = NativeSolver{}
+
+	_ InitialSolver = LisSolver{}
+	_ InitialSolver = NativeSolver{}
+)
+
+// LisSolver is solver based on external `lsolve` executable of `lis`
+// software.
+type LisSolver struct {
+	// Path is location of `lis` software, see LisPath
+	Path string
+
+	// TempDir is base folder for temp files, see LisTempDir
+	TempDir string
+
+	// KeepOnFailure is flag for keeping temp files in case of error,
+	// see LisKeepOnFailure
+	KeepOnFailure bool
+}
+
+// NativeSolver is in-process solver without external `lis` software.
+// See description of LsolveNative.
+type NativeSolver struct {
+	// Preconditioner is used, if it is not nil. Preconditioner must be
+	// created for same matrix A, so it can be reused for several solves.
+	// If size of preconditioner is not same as matrix A or options have
+	// preconditioner, then error IllOption is returned. For example:
+	//
+	//	M, err := golis.NewIC0(A)
+	//	...
+	//	solver := golis.NativeSolver{Preconditioner: M}
+	Preconditioner Preconditioner
+}
+
+// Solve returns solution matrix of iterative solve for linear system.
+// See description of LsolveNative.
+func (s NativeSolver) Solve(A, b mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return s.SolveInitial(A, b, nil, options)
+}
+
+// SolveInitial returns solution matrix of iterative solve for linear
+// system with initial guess x0. See description of LsolveNativeInitial.
+func (s NativeSolver) SolveInitial(A, b, x0 mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+
+	if isNil(x0) {
+		x0 = nil
+	}
+
+	// several right-hand vectors
+	if _, c := b.Dims(); c > 1 && x0 == nil {
+		var (
+			rhs  [][]float64
+			outs []string
+		)
+		solution, rhs, outs, err = s.SolveMultiple(A, b, options)
+		rhistory, output = joinResults(rhs, outs)
+		return
+	}
+
+	if err = checkSystem(A, b); err != nil {
+		return
+	}
+
+	var xv []float64
+	if x0 != nil {
+		if err = checkInitial(A, x0); err != nil {
+			return
+		}
+		xv = vectorFromMatrix(x0)
+		options = "-initx_zeros false " + options
+	}
+
+	p, err := s.prepare(A, options)
+	if err != nil {
+		return
+	}
+
+	start := time.Now()
+	x, rhistory, err := p.opt.solve(p.mv, vectorFromMatrix(b), xv, p.M)
+	elapsed := time.Since(start)
+
+	n, _ := b.Dims()
+	output = nativeOutput(p.opt, n, rhistory, p.name, p.time, elapsed, err)
+	if err != nil {
+		return
+	}
+	solution = mat.NewDense(n, 1, x)
+	return
+}
+
+// SolveMultiple returns solution matrix of iterative solve for linear
+// systems with several right-hand vectors.
+// See description of LsolveNativeMultiple.
+func (s NativeSolver) SolveMultiple(A, B mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory [][]float64,
+	output []string,
+	err error) {
+
+	if err = checkMultipleSystem(A, B); err != nil {
+		return
+	}
+
+	p, err := s.prepare(A, options)
+	if err != nil {
+		return
+	}
+
+	n, k := B.Dims()
+	sol := mat.NewDense(n, k, nil)
+	for j := 0; j < k; j++ {
+		start := time.Now()
+		x, rh, err := p.opt.solve(p.mv, mat.Col(nil, j, B), nil, p.M)
+		elapsed := time.Since(start)
+		rhistory = append(rhistory, rh)
+		output = append(output, nativeOutput(p.opt, n, rh, p.name, p.time, elapsed, err))
+		if err != nil {
+			return nil, rhistory, output, err
+		}
+		sol.SetCol(j, x)
+	}
+	solution = sol
+	return
+}
+
+// nativePrepared is matrix and preconditioner prepared for solving
+type nativePrepared struct {
+	opt  nativeOptions
+	mv   matVec
+	M    Preconditioner
+	name string        // name of preconditioner in output
+	time time.Duration // time of preconditioner creation
+}
+
+// prepare returns parsed options, matrix and preconditioner
+func (s NativeSolver) prepare(A mat.Matrix, options string) (
+	p nativePrepared, err error) {
+
+	if p.opt, err = parseNativeOptions(options); err != nil {
+		return
+	}
+	if p.opt.solver == "cg" && !isSymmetric(A) {
+		err = IllOption
+		return
+	}
+
+	var ok bool
+	if p.mv, ok = A.(matVec); !ok {
+		p.mv = convertToSparse(A)
+	}
+
+	if s.Preconditioner != nil {
+		// option of preconditioner conflicts with user defined
+		if p.opt.precond != "" {
+			err = IllOption
+			return
+		}
+		if r, _ := A.Dims(); s.Preconditioner.Size() != r {
+			err = IllOption
+			return
+		}
+		p.M, p.name = s.Preconditioner, "user defined"
+		return
+	}
+	start := time.Now()
+	p.M, p.name, err = p.opt.preconditioner(A)
+	p.time = time.Since(start)
+	return
+}
+
+// SolverFunc is adapter for using function as Solver.
+type SolverFunc func(A, b mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error)
+
+// Solve calls f(A, b, options).
+func (f SolverFunc) Solve(A, b mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	return f(A, b, options)
+}
+
+// DefaultSolver is solver used by function Solve.
+// If DefaultSolver is nil, then Lsolve is used.
+// For example, for using in-process solver:
+//
+//	golis.DefaultSolver = golis.NativeSolver{}
+//
+// DefaultSolver is read by Solve without synchronization, so it must be
+// set only during program initialization, before any call of Solve.
+// For different solvers in goroutines use method Solve of solver.
+var DefaultSolver Solver
+
+// Solve returns solution matrix of iterative solve for linear system
+// by DefaultSolver.
+//
+//	A * x = b
+//
+// Where: A is matrix, b is right-hand vector.
+func Solve(A, b mat.Matrix, options string) (
+	solution mat.Matrix,
+	rhistory []float64,
+	output string,
+	err error) {
+	if DefaultSolver == nil {
+		return Lsolve(A, b, options)
+	}
+	return DefaultSolver.Solve(A, b, options)
+}
@@ -0,0 +1,66 @@
+package golis_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSolver(t *testing.T) {
+	A := mat.NewDense(2, 2, []float64{
+		1.0, 2.0,
+		4.0, 1.0,
+	})
+	b := mat.NewDense(2, 1, []float64{
+		4.0,
+		9.0,
+	})
+
+	var called int
+	fake := golis.SolverFunc(func(A, b mat.Matrix, options string) (
+		solution mat.Matrix, rhistory []float64, output string, err error) {
+		called++
+		return mat.NewDense(2, 1, []float64{2, 1}), []float64{1, 0}, "fake", nil
+	})
+
+	for i, solver := range []golis.Solver{
+		golis.NativeSolver{},
+		fake,
+	} {
+		t.Run(fmt.Sprintf("Solver%d", i), func(t *testing.T) {
+			s, _, _, err := solver.Solve(A, b, "")
+			if err != nil {
+				t.Fatalf("Not correct result: %v", err)
+			}
+			if math.Abs(s.At(0, 0)-2) >= 1e-10 {
+				t.Errorf("Element 0,0 is not correct : %v", s.At(0, 0))
+			}
+			if math.Abs(s.At(1, 0)-1) >= 1e-10 {
+				t.Errorf("Element 1,0 is not correct : %v", s.At(1, 0))
+			}
+		})
+	}
+
+	t.Run("DefaultSolver", func(t *testing.T) {
+		defer func(s golis.Solver) { golis.DefaultSolver = s }(golis.DefaultSolver)
+		golis.DefaultSolver = fake
+		called = 0
+		_, _, output, err := golis.Solve(A, b, "")
+		if err != nil {
+			t.Fatalf("Not correct result: %v", err)
+		}
+		if called != 1 || output != "fake" {
+			t.Errorf("Default solver is not used: %d `%s`", called, output)
+		}
+	})
+
+	t.Run("LisSolverNotFound", func(t *testing.T) {
+		_, _, _, err := golis.LisSolver{Path: "/not/exist/path"}.Solve(A, b, "")
+		if err == nil {
+			t.Fatalf("Haven`t error : %v", err)
+		}
+	})
+}
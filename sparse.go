@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"sync"
 
 	"github.com/Konstantin8105/errors"
 	"gonum.org/v1/gonum/mat"
@@ -12,30 +13,75 @@ import (
 // guarantee SparseMatrix have interface of gonum.mat.Matrix
 var _ mat.Matrix = (*SparseMatrix)(nil)
 
+// guarantee SparseMatrix have interfaces of gonum for non-zero elements
+var (
+	_ mat.NonZeroDoer    = (*SparseMatrix)(nil)
+	_ mat.RowNonZeroDoer = (*SparseMatrix)(nil)
+	_ mat.ColNonZeroDoer = (*SparseMatrix)(nil)
+)
+
 type triple struct {
 	position int64   // position matrix element (row + column * size)
 	d        float64 // data
 }
 
-// byTriple implements sort.Interface based on the position field.
-type byTriple []triple
-
-func (a byTriple) Len() int           { return len(a) }
-func (a byTriple) Less(i, j int) bool { return a[i].position < a[j].position }
-func (a byTriple) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+// stableSort sorts triples by position. Order of triples with same
+// position is kept. Slice buf is temporary storage with same size.
+// Result is ts or buf.
+func stableSort(ts, buf []triple) []triple {
+	// insertion sort of small blocks
+	const block = 32
+	for from := 0; from < len(ts); from += block {
+		to := from + block
+		if to > len(ts) {
+			to = len(ts)
+		}
+		for i := from + 1; i < to; i++ {
+			for j := i; j > from && ts[j].position < ts[j-1].position; j-- {
+				ts[j], ts[j-1] = ts[j-1], ts[j]
+			}
+		}
+	}
+	// merge of neighbour blocks
+	for width := block; width < len(ts); width *= 2 {
+		for from := 0; from < len(ts); from += 2 * width {
+			mid, to := from+width, from+2*width
+			if mid > len(ts) {
+				mid = len(ts)
+			}
+			if to > len(ts) {
+				to = len(ts)
+			}
+			mergeTriples(ts[from:mid], ts[mid:to], buf[from:to])
+		}
+		ts, buf = buf, ts
+	}
+	return ts
+}
 
 // TODO add research for finding limit size
 // TODO create guarantee for memory = amount of non-zero element + size
 // TODO use memory blocks for triples separate by size L2 cache
 
-// SparseMatrix is struct of sparse matrix
+// SparseMatrix is struct of sparse matrix.
+//
+// Compression of triples and index of rows are created under lock, so
+// methods without changes of matrix are safe for concurrent use.
+// Methods with changes of matrix, for example Add and Set, are not safe
+// for concurrent use, see Assembler for concurrent assembly.
 type SparseMatrix struct {
 	r    int // amount of matrix rows
 	c    int // amount of matrix columns
 	data struct {
 		ts          []triple // non-zero value in matrix
 		amountAdded int      // amount unsorted of triples
+		buf         []triple // temporary storage for sorting, reused by compressions
+
+		// index of triples in order of rows, see rowIndex
+		rowPtr []int // start of row i in rowTs
+		rowTs  []int // indexes of triples
 	}
+	mu sync.Mutex // lock for merging of assemblers, compression and row index
 }
 
 // NewSparseMatrix return new sparse square matrix
@@ -154,32 +200,46 @@ func checkValue(v float64) {
 }
 
 func (m *SparseMatrix) check(r, c int) {
+	checkIndexes(r, c, m.r, m.c)
+}
+
+// checkIndexes is panic if indexes r, c is outside of matrix
+// with sizes [rows, cols]
+func checkIndexes(r, c, rows, cols int) {
 	var et errors.Tree
 	et.Name = "Check input indexes of element"
 
 	if r < 0 {
 		et.Add(fmt.Errorf("Index of rows cannot be less zero : %d", r))
 	}
-	if r >= m.r {
-		et.Add(fmt.Errorf("Index of rows is outside of matrix: %d of %d", r, m.r))
+	if r >= rows {
+		et.Add(fmt.Errorf("Index of rows is outside of matrix: %d of %d", r, rows))
 	}
 	if c < 0 {
 		et.Add(fmt.Errorf("Index of columns cannot be less zero : %d", c))
 	}
-	if c >= m.c {
-		et.Add(fmt.Errorf("Index of columns is outside of matrix: %d of %d", c, m.c))
+	if c >= cols {
+		et.Add(fmt.Errorf("Index of columns is outside of matrix: %d of %d", c, cols))
 	}
 	if et.IsError() {
 		panic(et)
 	}
 }
 
-// compress triples data. Example of triples:
+// compress triples data under lock of matrix. See compressLocked.
+func (m *SparseMatrix) compress() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.compressLocked()
+}
+
+// compressLocked compress triples data. Lock of matrix must be taken.
+// Example of triples:
 // [row column data]
 // Before compression: [1 1 0.1] [1 2 0.5] [1 1 0.5]
 // Intermediante     : [1 1 0.6] [1 2 0.5] [1 1 0.0]
 // After  compression: [1 1 0.6] [1 2 0.5]
-func (m *SparseMatrix) compress() {
+func (m *SparseMatrix) compressLocked() {
 	// check only with zero for force compression in
 	// parsing case
 	if m.data.amountAdded == 0 {
@@ -187,8 +247,19 @@ func (m *SparseMatrix) compress() {
 		return
 	}
 
-	// sort by position
-	sort.Sort(byTriple(m.data.ts))
+	// order of triples is changed
+	m.data.rowPtr, m.data.rowTs = nil, nil
+
+	// sort by position, parallel for large amount of unsorted triples
+	workers := compressWorkers(m.unsorted())
+	m.sortTriples(workers)
+
+	// parallel summation for large amount of triples
+	if workers > 1 {
+		m.data.ts = sumParallel(m.data.ts, workers)
+		m.data.amountAdded = 0
+		return
+	}
 
 	// summarize element with same indexes row, column and add 0.0 in old element
 	for i := 1; i < len(m.data.ts); i++ {
@@ -259,6 +330,52 @@ func (m *SparseMatrix) compress() {
 
 // TODO : fmt.Formatted
 
+// unsorted returns amount of unsorted triples at the end of slice
+func (m *SparseMatrix) unsorted() int {
+	if amount := m.data.amountAdded; 0 <= amount && amount <= len(m.data.ts) {
+		return amount
+	}
+	return len(m.data.ts)
+}
+
+// sortTriples sorts triples by position. Last amountAdded triples are
+// unsorted, other triples are sorted by previous compression, so only
+// unsorted triples are sorted by workers goroutines and merged with
+// others. Order of triples with same position is kept, so values are
+// summarized in order of addition and result does not depend on
+// moments of compression. Slice of triples keeps capacity.
+func (m *SparseMatrix) sortTriples(workers int) {
+	ts := m.data.ts
+	amount := m.unsorted()
+	if amount == 0 {
+		return
+	}
+	if cap(m.data.buf) < amount {
+		m.data.buf = make([]triple, amount)
+	}
+	buf := m.data.buf[:amount]
+	var tail []triple
+	if workers > 1 {
+		tail = sortParallel(ts[len(ts)-amount:], buf, workers)
+	} else {
+		tail = stableSort(ts[len(ts)-amount:], buf)
+	}
+	if &tail[0] != &buf[0] {
+		copy(buf, tail)
+	}
+	// merge from the end, sorted triples are not overwritten before use
+	i, j := len(ts)-amount-1, amount-1
+	for k := len(ts) - 1; j >= 0; k-- {
+		if i >= 0 && ts[i].position > buf[j].position {
+			ts[k] = ts[i]
+			i--
+		} else {
+			ts[k] = buf[j]
+			j--
+		}
+	}
+}
+
 // String return standard golis string of sparse matrix
 func (m *SparseMatrix) String() string {
 	m.compress()
@@ -364,3 +481,140 @@ func (m *SparseMatrix) appendTriple(x []triple, y triple) []triple {
 	z[len(x)] = y
 	return z
 }
+
+// mulVec calculate vector y = m * x by triples of sparse matrix.
+// Length of x must be amount of columns, length of y - amount of rows.
+func (m *SparseMatrix) mulVec(y, x []float64) {
+	m.compress()
+	for i := range y {
+		y[i] = 0.0
+	}
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		y[r] += m.data.ts[i].d * x[c]
+	}
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements
+// of matrix. Elements are visited in order of columns. Stored zero
+// elements are skipped. The function fn must not change the matrix.
+func (m *SparseMatrix) DoNonZero(fn func(i, j int, v float64)) {
+	m.compress()
+	for i := range m.data.ts {
+		if m.data.ts[i].d == 0.0 {
+			continue
+		}
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		fn(r, c, m.data.ts[i].d)
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements
+// of row i of matrix. Stored zero elements are skipped.
+// The function fn must not change the matrix.
+func (m *SparseMatrix) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	m.check(i, 0)
+	m.compress()
+	ptr, ts := m.rowIndex()
+	for _, k := range ts[ptr[i]:ptr[i+1]] {
+		if m.data.ts[k].d == 0.0 {
+			continue
+		}
+		fn(i, int(m.data.ts[k].position/int64(m.r)), m.data.ts[k].d)
+	}
+}
+
+// rowIndex returns index of compressed triples in order of rows.
+// Index is created only once and removed by compression, so iteration
+// of all rows is O(rows + nnz). Index is created under lock, so
+// concurrent reading of matrix is safe.
+func (m *SparseMatrix) rowIndex() (rowPtr, rowTs []int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.data.rowPtr != nil {
+		return m.data.rowPtr, m.data.rowTs
+	}
+	ptr := make([]int, m.r+1)
+	for k := range m.data.ts {
+		ptr[int(m.data.ts[k].position%int64(m.r))+1]++
+	}
+	for i := 0; i < m.r; i++ {
+		ptr[i+1] += ptr[i]
+	}
+	// triples are sorted by columns, so columns in rows are sorted
+	ts := make([]int, len(m.data.ts))
+	next := make([]int, m.r)
+	copy(next, ptr)
+	for k := range m.data.ts {
+		r := int(m.data.ts[k].position % int64(m.r))
+		ts[next[r]] = k
+		next[r]++
+	}
+	m.data.rowPtr, m.data.rowTs = ptr, ts
+	return ptr, ts
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements
+// of column j of matrix. Stored zero elements are skipped.
+// The function fn must not change the matrix.
+func (m *SparseMatrix) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	m.check(0, j)
+	m.compress()
+	from, to := m.column(j)
+	for k := from; k < to; k++ {
+		if m.data.ts[k].d == 0.0 {
+			continue
+		}
+		fn(int(m.data.ts[k].position%int64(m.r)), j, m.data.ts[k].d)
+	}
+}
+
+// column returns range of triples in column c
+func (m *SparseMatrix) column(c int) (from, to int) {
+	begin := int64(c) * int64(m.r)
+	end := begin + int64(m.r)
+	from = sort.Search(len(m.data.ts), func(i int) bool {
+		return m.data.ts[i].position >= begin
+	})
+	to = from + sort.Search(len(m.data.ts)-from, func(i int) bool {
+		return m.data.ts[from+i].position >= end
+	})
+	return
+}
+
+// SparseIterator is iterator over stored non-zero elements of
+// sparse matrix. Matrix must not be changed during iteration.
+//
+// Example:
+//
+//	it := m.Iterator()
+//	for it.Next() {
+//		r, c, v := it.Value()
+//		...
+//	}
+type SparseIterator struct {
+	m   *SparseMatrix
+	pos int
+}
+
+// Iterator returns iterator over stored non-zero elements in order
+// of columns.
+func (m *SparseMatrix) Iterator() *SparseIterator {
+	m.compress()
+	return &SparseIterator{m: m, pos: -1}
+}
+
+// Next moves iterator to next element and returns false,
+// if elements are finished.
+func (it *SparseIterator) Next() bool {
+	it.pos++
+	return it.pos < len(it.m.data.ts)
+}
+
+// Value returns row, column and value of current element.
+func (it *SparseIterator) Value() (r, c int, v float64) {
+	t := it.m.data.ts[it.pos]
+	return int(t.position % int64(it.m.r)), int(t.position / int64(it.m.r)), t.d
+}
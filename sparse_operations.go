@@ -0,0 +1,230 @@
+package golis
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MulVec returns result of multiplication matrix on vector
+//
+//	y = m * x
+//
+// It will panic if size of vector x is not same as amount of columns.
+func (m *SparseMatrix) MulVec(x mat.Vector) *mat.VecDense {
+	if x.Len() != m.c {
+		panic(fmt.Errorf("Size of vector is not valid: %d != %d", x.Len(), m.c))
+	}
+	y := make([]float64, m.r)
+	m.mulVec(y, vectorFromMatrix(x))
+	return mat.NewVecDense(m.r, y)
+}
+
+// MulVecTrans returns result of multiplication transposed matrix on vector
+//
+//	y = mᵀ * x
+//
+// It will panic if size of vector x is not same as amount of rows.
+func (m *SparseMatrix) MulVecTrans(x mat.Vector) *mat.VecDense {
+	if x.Len() != m.r {
+		panic(fmt.Errorf("Size of vector is not valid: %d != %d", x.Len(), m.r))
+	}
+	m.compress()
+	y := make([]float64, m.c)
+	for i := range m.data.ts {
+		r := int(m.data.ts[i].position % int64(m.r))
+		c := int(m.data.ts[i].position / int64(m.r))
+		y[c] += m.data.ts[i].d * x.AtVec(r)
+	}
+	return mat.NewVecDense(m.c, y)
+}
+
+// Mul returns sparse result of multiplication of sparse matrixes
+//
+//	result = m * b
+//
+// It will panic if amount of columns m is not same as amount of rows b.
+func (m *SparseMatrix) Mul(b *SparseMatrix) *SparseMatrix {
+	if m.c != b.r {
+		panic(fmt.Errorf("Sizes of matrixes are not valid: [%d,%d] * [%d,%d]",
+			m.r, m.c, b.r, b.c))
+	}
+	return mulCSR(m.CSR(), b.CSR())
+}
+
+// mulCSR returns sparse result of multiplication of matrixes by
+// algorithm of Gustavson
+func mulCSR(a, b *CSR) *SparseMatrix {
+	out := NewSparseMatrix(a.r, b.c)
+	var (
+		acc  = make([]float64, b.c) // accumulator of row values
+		mark = make([]int, b.c)     // last row with value in column
+		cols = make([]int, 0, b.c)  // columns with values in row
+	)
+	for i := range mark {
+		mark[i] = -1
+	}
+	for r := 0; r < a.r; r++ {
+		cols = cols[:0]
+		aInd, aData := a.cs.vector(r)
+		for k := range aInd {
+			bInd, bData := b.cs.vector(aInd[k])
+			for p := range bInd {
+				c := bInd[p]
+				if mark[c] != r {
+					mark[c] = r
+					acc[c] = 0.0
+					cols = append(cols, c)
+				}
+				acc[c] += aData[k] * bData[p]
+			}
+		}
+		for _, c := range cols {
+			if acc[c] == 0.0 {
+				continue
+			}
+			out.data.ts = append(out.data.ts, triple{
+				position: int64(r) + int64(c)*int64(out.r),
+				d:        acc[c],
+			})
+		}
+	}
+	out.data.amountAdded = -1
+	out.compress()
+	return out
+}
+
+// AddMatrix returns sparse result of addition of sparse matrixes
+//
+//	result = m + b
+//
+// It will panic if sizes of matrixes are not same.
+func (m *SparseMatrix) AddMatrix(b *SparseMatrix) *SparseMatrix {
+	return m.merge(b, 1.0)
+}
+
+// SubMatrix returns sparse result of subtraction of sparse matrixes
+//
+//	result = m - b
+//
+// It will panic if sizes of matrixes are not same.
+func (m *SparseMatrix) SubMatrix(b *SparseMatrix) *SparseMatrix {
+	return m.merge(b, -1.0)
+}
+
+// merge returns result of m + factor * b by merging of sorted triples
+func (m *SparseMatrix) merge(b *SparseMatrix, factor float64) *SparseMatrix {
+	if m.r != b.r || m.c != b.c {
+		panic(fmt.Errorf("Sizes of matrixes are not same: [%d,%d] != [%d,%d]",
+			m.r, m.c, b.r, b.c))
+	}
+	m.compress()
+	b.compress()
+	out := NewSparseMatrix(m.r, m.c)
+	out.data.ts = make([]triple, 0, len(m.data.ts)+len(b.data.ts))
+	i, j := 0, 0
+	for i < len(m.data.ts) || j < len(b.data.ts) {
+		var t triple
+		switch {
+		case j >= len(b.data.ts) ||
+			(i < len(m.data.ts) && m.data.ts[i].position < b.data.ts[j].position):
+			t = m.data.ts[i]
+			i++
+		case i >= len(m.data.ts) || b.data.ts[j].position < m.data.ts[i].position:
+			t = triple{position: b.data.ts[j].position, d: factor * b.data.ts[j].d}
+			j++
+		default:
+			// same position
+			t = triple{
+				position: m.data.ts[i].position,
+				d:        m.data.ts[i].d + factor*b.data.ts[j].d,
+			}
+			i++
+			j++
+		}
+		if t.d == 0.0 {
+			continue
+		}
+		out.data.ts = append(out.data.ts, t)
+	}
+	return out
+}
+
+// Scale returns sparse result of multiplication matrix on factor
+//
+//	result = factor * m
+//
+// If factor is not valid, then create panic.
+func (m *SparseMatrix) Scale(factor float64) *SparseMatrix {
+	checkValue(factor)
+	m.compress()
+	out := NewSparseMatrix(m.r, m.c)
+	if factor == 0.0 {
+		return out
+	}
+	out.data.ts = make([]triple, len(m.data.ts))
+	for i := range m.data.ts {
+		out.data.ts[i] = triple{
+			position: m.data.ts[i].position,
+			d:        factor * m.data.ts[i].d,
+		}
+	}
+	return out
+}
+
+// MulVec returns result of multiplication matrix on vector by
+// only stored upper triangle
+//
+//	y = m * x
+//
+// It will panic if size of vector x is not same as size of matrix.
+func (m *SparseMatrixSymmetric) MulVec(x mat.Vector) *mat.VecDense {
+	if x.Len() != m.s.r {
+		panic(fmt.Errorf("Size of vector is not valid: %d != %d", x.Len(), m.s.r))
+	}
+	y := make([]float64, m.s.r)
+	m.mulVec(y, vectorFromMatrix(x))
+	return mat.NewVecDense(m.s.r, y)
+}
+
+// Mul returns sparse result of multiplication of symmetric matrixes.
+// Result is not symmetric in general case.
+//
+//	result = m * b
+//
+// It will panic if sizes of matrixes are not same.
+func (m *SparseMatrixSymmetric) Mul(b *SparseMatrixSymmetric) *SparseMatrix {
+	if m.s.r != b.s.r {
+		panic(fmt.Errorf("Sizes of matrixes are not same: %d != %d", m.s.r, b.s.r))
+	}
+	return mulCSR(m.CSR(), b.CSR())
+}
+
+// AddMatrix returns symmetric sparse result of addition of matrixes
+// by only stored upper triangles
+//
+//	result = m + b
+//
+// It will panic if sizes of matrixes are not same.
+func (m *SparseMatrixSymmetric) AddMatrix(b *SparseMatrixSymmetric) *SparseMatrixSymmetric {
+	return &SparseMatrixSymmetric{s: m.s.merge(b.s, 1.0)}
+}
+
+// SubMatrix returns symmetric sparse result of subtraction of matrixes
+// by only stored upper triangles
+//
+//	result = m - b
+//
+// It will panic if sizes of matrixes are not same.
+func (m *SparseMatrixSymmetric) SubMatrix(b *SparseMatrixSymmetric) *SparseMatrixSymmetric {
+	return &SparseMatrixSymmetric{s: m.s.merge(b.s, -1.0)}
+}
+
+// Scale returns symmetric sparse result of multiplication matrix on factor
+//
+//	result = factor * m
+//
+// If factor is not valid, then create panic.
+func (m *SparseMatrixSymmetric) Scale(factor float64) *SparseMatrixSymmetric {
+	return &SparseMatrixSymmetric{s: m.s.Scale(factor)}
+}
@@ -0,0 +1,196 @@
+package golis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Konstantin8105/golis"
+	"gonum.org/v1/gonum/mat"
+)
+
+// sparseFromDense returns sparse matrix with values of dense matrix
+func sparseFromDense(a mat.Matrix) *golis.SparseMatrix {
+	r, c := a.Dims()
+	s := golis.NewSparseMatrix(r, c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			s.Add(i, j, a.At(i, j))
+		}
+	}
+	return s
+}
+
+// symmetricFromDense returns symmetric sparse matrix with values
+// of upper triangle of dense matrix
+func symmetricFromDense(a mat.Matrix) *golis.SparseMatrixSymmetric {
+	r, _ := a.Dims()
+	s := golis.NewSparseMatrixSymmetric(r)
+	for i := 0; i < r; i++ {
+		for j := i; j < r; j++ {
+			s.Add(i, j, a.At(i, j))
+		}
+	}
+	return s
+}
+
+func TestSparseOperations(t *testing.T) {
+	a := mat.NewDense(3, 4, []float64{
+		8, 0, 6, 0,
+		0, 0, 0, -1,
+		4, 1, 0, 2,
+	})
+	b := mat.NewDense(3, 4, []float64{
+		-8, 1, 0, 0,
+		0, 5, 0, 1,
+		0, 1, 0, 2,
+	})
+	c := mat.NewDense(4, 2, []float64{
+		1, 0,
+		0, 2,
+		3, 0,
+		0, 4,
+	})
+	x := mat.NewVecDense(4, []float64{1, 2, 3, 4})
+	xt := mat.NewVecDense(3, []float64{1, -2, 3})
+	sa, sb, sc := sparseFromDense(a), sparseFromDense(b), sparseFromDense(c)
+
+	t.Run("MulVec", func(t *testing.T) {
+		var expect mat.VecDense
+		expect.MulVec(a, x)
+		if !mat.Equal(sa.MulVec(x), &expect) {
+			t.Fatalf("Not same: %v", sa.MulVec(x))
+		}
+	})
+
+	t.Run("MulVecTrans", func(t *testing.T) {
+		var expect mat.VecDense
+		expect.MulVec(a.T(), xt)
+		if !mat.Equal(sa.MulVecTrans(xt), &expect) {
+			t.Fatalf("Not same: %v", sa.MulVecTrans(xt))
+		}
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		var expect mat.Dense
+		expect.Mul(a, c)
+		if !isSame(sa.Mul(sc), &expect) {
+			t.Fatalf("Not same:\n%s", sa.Mul(sc))
+		}
+	})
+
+	t.Run("AddMatrix", func(t *testing.T) {
+		var expect mat.Dense
+		expect.Add(a, b)
+		result := sa.AddMatrix(sb)
+		if !isSame(result, &expect) {
+			t.Fatalf("Not same:\n%s", result)
+		}
+		// zero value [0,0] is not stored
+		if csr := result.CSR(); csr.NNZ() != 6 {
+			t.Errorf("Not valid amount of elements: %d", csr.NNZ())
+		}
+	})
+
+	t.Run("SubMatrix", func(t *testing.T) {
+		var expect mat.Dense
+		expect.Sub(a, b)
+		if !isSame(sa.SubMatrix(sb), &expect) {
+			t.Fatalf("Not same:\n%s", sa.SubMatrix(sb))
+		}
+		if csr := sa.SubMatrix(sa).CSR(); csr.NNZ() != 0 {
+			t.Errorf("Not empty matrix: %d", csr.NNZ())
+		}
+	})
+
+	t.Run("Scale", func(t *testing.T) {
+		var expect mat.Dense
+		expect.Scale(-2.5, a)
+		if !isSame(sa.Scale(-2.5), &expect) {
+			t.Fatalf("Not same:\n%s", sa.Scale(-2.5))
+		}
+		if csr := sa.Scale(0.0).CSR(); csr.NNZ() != 0 {
+			t.Errorf("Not empty matrix: %d", csr.NNZ())
+		}
+	})
+}
+
+func TestSparseSymmetricOperations(t *testing.T) {
+	a := mat.NewSymDense(3, []float64{
+		8, 1, 6,
+		1, 5, 7,
+		6, 7, 2,
+	})
+	b := mat.NewSymDense(3, []float64{
+		-8, 0, 1,
+		0, 5, 0,
+		1, 0, 2,
+	})
+	x := mat.NewVecDense(3, []float64{1, -2, 3})
+	sa, sb := symmetricFromDense(a), symmetricFromDense(b)
+
+	t.Run("MulVec", func(t *testing.T) {
+		var expect mat.VecDense
+		expect.MulVec(a, x)
+		if !mat.Equal(sa.MulVec(x), &expect) {
+			t.Fatalf("Not same: %v", sa.MulVec(x))
+		}
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		var expect mat.Dense
+		expect.Mul(a, b)
+		if !isSame(sa.Mul(sb), &expect) {
+			t.Fatalf("Not same:\n%s", sa.Mul(sb))
+		}
+	})
+
+	t.Run("AddMatrix", func(t *testing.T) {
+		var expect mat.Dense
+		expect.Add(a, b)
+		if !isSame(sa.AddMatrix(sb), &expect) {
+			t.Fatalf("Not same:\n%s", sa.AddMatrix(sb))
+		}
+	})
+
+	t.Run("SubMatrix", func(t *testing.T) {
+		var expect mat.Dense
+		expect.Sub(a, b)
+		if !isSame(sa.SubMatrix(sb), &expect) {
+			t.Fatalf("Not same:\n%s", sa.SubMatrix(sb))
+		}
+	})
+
+	t.Run("Scale", func(t *testing.T) {
+		var expect mat.Dense
+		expect.Scale(3, a)
+		if !isSame(sa.Scale(3), &expect) {
+			t.Fatalf("Not same:\n%s", sa.Scale(3))
+		}
+	})
+}
+
+func TestSparseOperationsPanics(t *testing.T) {
+	a := golis.NewSparseMatrix(2, 3)
+	s := golis.NewSparseMatrixSymmetric(2)
+	for i, f := range []func(){
+		func() { a.MulVec(mat.NewVecDense(2, nil)) },
+		func() { a.MulVecTrans(mat.NewVecDense(3, nil)) },
+		func() { a.Mul(golis.NewSparseMatrix(2, 3)) },
+		func() { a.AddMatrix(golis.NewSparseMatrix(3, 2)) },
+		func() { a.SubMatrix(golis.NewSparseMatrix(2, 2)) },
+		func() { s.MulVec(mat.NewVecDense(3, nil)) },
+		func() { s.Mul(golis.NewSparseMatrixSymmetric(3)) },
+		func() { s.AddMatrix(golis.NewSparseMatrixSymmetric(3)) },
+	} {
+		t.Run(fmt.Sprintf("Panic%d", i), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				t.Logf("\n%v", r)
+				if r == nil {
+					t.Fatal("Haven`t panic for not valid data")
+				}
+			}()
+			f()
+		})
+	}
+}
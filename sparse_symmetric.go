@@ -9,6 +9,14 @@ import (
 // guarantee SparseMatrix have interface of gonum.mat.Matrix
 var _ mat.MutableSymmetric = (*SparseMatrixSymmetric)(nil)
 
+// guarantee SparseMatrixSymmetric have interfaces of gonum for
+// non-zero elements
+var (
+	_ mat.NonZeroDoer    = (*SparseMatrixSymmetric)(nil)
+	_ mat.RowNonZeroDoer = (*SparseMatrixSymmetric)(nil)
+	_ mat.ColNonZeroDoer = (*SparseMatrixSymmetric)(nil)
+)
+
 // SparseMatrixSymmetric is struct of sparse matrix
 type SparseMatrixSymmetric struct {
 	s *SparseMatrix
@@ -81,3 +89,64 @@ func (m *SparseMatrixSymmetric) SetZeroForRowColumn(rc int) {
 func (m *SparseMatrixSymmetric) String() string {
 	return m.s.String()
 }
+
+// mulVec calculate vector y = m * x by triples of upper triangle
+// of symmetric sparse matrix.
+func (m *SparseMatrixSymmetric) mulVec(y, x []float64) {
+	m.s.compress()
+	for i := range y {
+		y[i] = 0.0
+	}
+	for i := range m.s.data.ts {
+		r := int(m.s.data.ts[i].position % int64(m.s.r))
+		c := int(m.s.data.ts[i].position / int64(m.s.r))
+		y[r] += m.s.data.ts[i].d * x[c]
+		if r != c {
+			y[c] += m.s.data.ts[i].d * x[r]
+		}
+	}
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements
+// of full symmetric matrix. Elements outside of diagonal are visited
+// twice: as [i,j] and as [j,i].
+// The function fn must not change the matrix.
+func (m *SparseMatrixSymmetric) DoNonZero(fn func(i, j int, v float64)) {
+	m.s.DoNonZero(func(i, j int, v float64) {
+		fn(i, j, v)
+		if i != j {
+			fn(j, i, v)
+		}
+	})
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements
+// of row i of full symmetric matrix.
+// The function fn must not change the matrix.
+func (m *SparseMatrixSymmetric) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	// lower triangle elements are stored in column i
+	m.s.DoColNonZero(i, func(r, c int, v float64) {
+		fn(i, r, v)
+	})
+	// upper triangle elements without diagonal
+	m.s.DoRowNonZero(i, func(r, c int, v float64) {
+		if c != i {
+			fn(i, c, v)
+		}
+	})
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements
+// of column j of full symmetric matrix.
+// The function fn must not change the matrix.
+func (m *SparseMatrixSymmetric) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	m.DoRowNonZero(j, func(i, c int, v float64) {
+		fn(c, j, v)
+	})
+}
+
+// Iterator returns iterator over stored non-zero elements of upper
+// triangle in order of columns.
+func (m *SparseMatrixSymmetric) Iterator() *SparseIterator {
+	return m.s.Iterator()
+}
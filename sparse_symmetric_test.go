@@ -430,3 +430,68 @@ func TestSparseMatrixSymmetric(t *testing.T) {
 // 		sp.Add(0, 0, math.NaN())
 // 	})
 // }
+
+func TestSparseMatrixSymmetricNonZero(t *testing.T) {
+	a := mat.NewDense(3, 3, []float64{
+		8, 0, 6,
+		0, 5, 7,
+		6, 7, 0,
+	})
+	s := golis.NewSparseMatrixSymmetric(3)
+	for i := 0; i < 3; i++ {
+		for j := i; j < 3; j++ {
+			s.Add(i, j, a.At(i, j))
+		}
+	}
+
+	t.Run("DoNonZero", func(t *testing.T) {
+		b := mat.NewDense(3, 3, nil)
+		var amount int
+		s.DoNonZero(func(i, j int, v float64) {
+			b.Set(i, j, v)
+			amount++
+		})
+		if amount != 6 || !mat.Equal(a, b) {
+			t.Fatalf("Not same: %d\n%v", amount, mat.Formatted(b))
+		}
+	})
+
+	t.Run("DoRowNonZero", func(t *testing.T) {
+		for r := 0; r < 3; r++ {
+			b := mat.NewDense(3, 3, nil)
+			s.DoRowNonZero(r, func(i, j int, v float64) {
+				b.Set(i, j, v)
+			})
+			if !mat.Equal(b.RowView(r), a.RowView(r)) {
+				t.Errorf("Not valid row %d: %v", r, mat.Formatted(b))
+			}
+		}
+	})
+
+	t.Run("DoColNonZero", func(t *testing.T) {
+		for c := 0; c < 3; c++ {
+			b := mat.NewDense(3, 3, nil)
+			s.DoColNonZero(c, func(i, j int, v float64) {
+				b.Set(i, j, v)
+			})
+			if !mat.Equal(b.ColView(c), a.ColView(c)) {
+				t.Errorf("Not valid column %d: %v", c, mat.Formatted(b))
+			}
+		}
+	})
+
+	t.Run("Iterator", func(t *testing.T) {
+		var amount int
+		it := s.Iterator()
+		for it.Next() {
+			r, c, v := it.Value()
+			if r > c || v != a.At(r, c) {
+				t.Errorf("Not valid element [%d,%d] = %v", r, c, v)
+			}
+			amount++
+		}
+		if amount != 4 {
+			t.Errorf("Not valid amount of elements: %d", amount)
+		}
+	})
+}
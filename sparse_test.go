@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/Konstantin8105/golis"
@@ -493,3 +494,141 @@ func TestSparseMatrixPanics(t *testing.T) {
 		sp.Add(0, 0, math.NaN())
 	})
 }
+
+func TestSparseMatrixNonZero(t *testing.T) {
+	a := mat.NewDense(3, 4, []float64{
+		8, 0, 6, 0,
+		0, 0, 0, -1,
+		4, 1, 0, 2,
+	})
+	s := golis.NewSparseMatrix(3, 4)
+	for i := 2; i >= 0; i-- {
+		for j := 0; j < 4; j++ {
+			s.Add(i, j, a.At(i, j))
+		}
+	}
+
+	t.Run("DoNonZero", func(t *testing.T) {
+		b := mat.NewDense(3, 4, nil)
+		var amount int
+		s.DoNonZero(func(i, j int, v float64) {
+			b.Set(i, j, v)
+			amount++
+		})
+		if amount != 6 || !mat.Equal(a, b) {
+			t.Fatalf("Not same: %d\n%v", amount, mat.Formatted(b))
+		}
+	})
+
+	t.Run("DoRowNonZero", func(t *testing.T) {
+		for r := 0; r < 3; r++ {
+			var cols []int
+			s.DoRowNonZero(r, func(i, j int, v float64) {
+				if i != r || v != a.At(i, j) || v == 0.0 {
+					t.Errorf("Not valid element [%d,%d] = %v", i, j, v)
+				}
+				cols = append(cols, j)
+			})
+			if fmt.Sprint(cols) != fmt.Sprint([][]int{{0, 2}, {3}, {0, 1, 3}}[r]) {
+				t.Errorf("Not valid columns in row %d: %v", r, cols)
+			}
+		}
+	})
+
+	t.Run("DoColNonZero", func(t *testing.T) {
+		for c := 0; c < 4; c++ {
+			var rows []int
+			s.DoColNonZero(c, func(i, j int, v float64) {
+				if j != c || v != a.At(i, j) || v == 0.0 {
+					t.Errorf("Not valid element [%d,%d] = %v", i, j, v)
+				}
+				rows = append(rows, i)
+			})
+			if fmt.Sprint(rows) != fmt.Sprint([][]int{{0, 2}, {2}, {0}, {1, 2}}[c]) {
+				t.Errorf("Not valid rows in column %d: %v", c, rows)
+			}
+		}
+	})
+
+	t.Run("Iterator", func(t *testing.T) {
+		var out string
+		it := s.Iterator()
+		for it.Next() {
+			r, c, v := it.Value()
+			out += fmt.Sprintf("[%d,%d]=%v ", r, c, v)
+		}
+		if out != "[0,0]=8 [2,0]=4 [2,1]=1 [0,2]=6 [1,3]=-1 [2,3]=2 " {
+			t.Errorf("Not valid iteration: %s", out)
+		}
+	})
+
+	t.Run("DoRowNonZeroAfterChange", func(t *testing.T) {
+		c := s.Scale(1.0)
+		c.DoRowNonZero(1, func(i, j int, v float64) {})
+		c.Add(1, 0, 5.0)
+		c.Set(1, 3, 0.0)
+		var out string
+		c.DoRowNonZero(1, func(i, j int, v float64) {
+			out += fmt.Sprintf("[%d,%d]=%v ", i, j, v)
+		})
+		if out != "[1,0]=5 " {
+			t.Errorf("Not valid row after change: %s", out)
+		}
+	})
+
+	t.Run("StoredZero", func(t *testing.T) {
+		c := s.Scale(1.0)
+		c.Set(1, 3, 0.0)
+		var out string
+		fn := func(i, j int, v float64) {
+			out += fmt.Sprintf("[%d,%d]=%v ", i, j, v)
+		}
+		c.DoNonZero(fn)
+		c.DoColNonZero(3, fn)
+		if out != "[0,0]=8 [2,0]=4 [2,1]=1 [0,2]=6 [2,3]=2 [2,3]=2 " {
+			t.Errorf("Stored zero is not skipped: %s", out)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		c := s.Scale(1.0)
+		// not compressed matrix
+		c.Add(1, 3, 1.0)
+		c.Add(1, 3, -1.0)
+		var wg sync.WaitGroup
+		sums := make([]float64, 8)
+		for g := range sums {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for r := 0; r < 3; r++ {
+					c.DoRowNonZero(r, func(_, _ int, v float64) {
+						sums[g] += v
+					})
+				}
+			}(g)
+		}
+		wg.Wait()
+		for g := range sums {
+			if sums[g] != 20 {
+				t.Errorf("Not valid sum in goroutine %d: %v", g, sums[g])
+			}
+		}
+	})
+}
+
+func BenchmarkDoRowNonZero(b *testing.B) {
+	for _, size := range []int{1000, 10000, 100000} {
+		s := bandMatrix(size, 5)
+		b.Run(fmt.Sprintf("AllRows:%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				var sum float64
+				for i := 0; i < size; i++ {
+					s.DoRowNonZero(i, func(_, _ int, v float64) {
+						sum += v
+					})
+				}
+			}
+		})
+	}
+}